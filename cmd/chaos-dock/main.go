@@ -17,12 +17,24 @@ import (
 	"github.com/lekhanpro/chaos-dock/internal/application/safety"
 	"github.com/lekhanpro/chaos-dock/internal/application/ui"
 	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	domaintarget "github.com/lekhanpro/chaos-dock/internal/domain/target"
 	configinfra "github.com/lekhanpro/chaos-dock/internal/infrastructure/config"
 	dockerinfra "github.com/lekhanpro/chaos-dock/internal/infrastructure/docker"
 	faultinfra "github.com/lekhanpro/chaos-dock/internal/infrastructure/fault"
 )
 
 func main() {
+	if faultinfra.IsHelperInvocation(os.Args) {
+		// Workload helpers (such as the cpu-stress burner) re-execute this binary inside a
+		// target container's cgroup.
+		if err := faultinfra.RunHelper(os.Args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
@@ -52,7 +64,11 @@ func main() {
 			if exp.Enabled {
 				status = "enabled"
 			}
-			log.Printf("- %s [%s] target=%s fault=%s every=%s", exp.Name, status, exp.TargetContainer, exp.Fault.Type, exp.Schedule.Every)
+			target := exp.TargetContainer
+			if exp.Target != nil {
+				target = "{" + domaintarget.Describe(*exp.Target) + "}"
+			}
+			log.Printf("- %s [%s] target=%s fault=%s every=%s", exp.Name, status, target, exp.Fault.Type, exp.Schedule.Every)
 		}
 		return
 	}
@@ -63,19 +79,23 @@ func main() {
 	}
 	defer runtime.Close()
 
-	latencyInjector := faultinfra.NewNetworkLatencyInjector(runtime)
-	killInjector := faultinfra.NewContainerKillInjector(runtime)
 	registry := safety.NewTargetRegistry()
 
+	faults, err := domainfault.NewBuiltinRegistry(faultinfra.NewInjectors(runtime))
+	if err != nil {
+		log.Fatalf("register built-in faults: %v", err)
+	}
+
 	runner := &engine.Runner{
-		Injector: latencyInjector,
-		Killer:   killInjector,
-		Tracker:  registry,
+		Faults:  faults,
+		Targets: runtime,
+		Tracker: registry,
 	}
 
 	panicButton := &safety.PanicButton{
-		Injector:  latencyInjector,
+		Faults:    faults,
 		Restarter: runtime,
+		States:    runtime,
 		Registry:  registry,
 	}
 
@@ -92,7 +112,7 @@ func main() {
 		return
 	}
 
-	cfg, err := configinfra.LoadChaosConfig(opts.configPath)
+	cfg, err := configinfra.LoadChaosConfigWithFaults(opts.configPath, faults)
 	if err != nil {
 		log.Fatalf("load config: %v", err)
 	}
@@ -214,6 +234,10 @@ func listContainers(ctx context.Context, runtime *dockerinfra.Runtime) {
 		if len(shortID) > 12 {
 			shortID = shortID[:12]
 		}
+		if service := c.Labels["com.docker.compose.service"]; service != "" {
+			log.Printf("- %s (%s) image=%s status=%s service=%s/%s", c.Name, shortID, c.Image, c.Status, c.Labels["com.docker.compose.project"], service)
+			continue
+		}
 		log.Printf("- %s (%s) image=%s status=%s", c.Name, shortID, c.Image, c.Status)
 	}
 }
@@ -2,33 +2,46 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
 	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
 	"github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	domaintarget "github.com/lekhanpro/chaos-dock/internal/domain/target"
 )
 
 type TargetTracker interface {
 	Mark(containerID string)
 }
 
+// Runner executes experiments through the plugin registered for their fault type.
 type Runner struct {
-	Injector fault.FaultInjector
-	Killer   fault.ContainerKiller
-	Tracker  TargetTracker
+	Faults  *fault.Registry
+	Targets domaintarget.Lister // resolves experiments that use a target selector
+	Tracker TargetTracker
 }
 
 type ExperimentResult struct {
-	Name            string
-	TargetContainer string
-	FaultType       string
-	StartedAt       time.Time
-	FinishedAt      time.Time
-	Skipped         bool
-	Message         string
-	Err             error
+	Name             string
+	TargetContainer  string
+	FaultType        string
+	StartedAt        time.Time
+	FinishedAt       time.Time
+	Skipped          bool
+	Message          string
+	OOMKilled        bool // Docker reported an OOM kill after a memory-stress fault
+	ConnectionsReset int  // established connections destroyed by a tcp-reset fault
+	ProcessesKilled  int  // processes signalled by a process-kill fault
+	// InjectedAt is taken just before the fault is injected. RevertedAt is when the fault was
+	// removed: after the runner's revert once fault.duration expires, when Inject returned for
+	// plugins that revert themselves, or after the cleanup of a failed injection. It stays zero
+	// while the fault is left in place or if the revert failed.
+	InjectedAt time.Time
+	RevertedAt time.Time
+	Err        error
 }
 
 func (r ExperimentResult) Duration() time.Duration {
@@ -38,12 +51,17 @@ func (r ExperimentResult) Duration() time.Duration {
 	return r.FinishedAt.Sub(r.StartedAt)
 }
 
-func (r *Runner) ApplyNetworkLatency(ctx context.Context, containerID string, delay time.Duration) error {
-	if r.Injector == nil {
-		return fmt.Errorf("fault injector is not configured")
-	}
+// holdAndRevert waits for hold or until ctx is cancelled, then reverts the fault. The revert
+// runs on a context detached from ctx, so a cancelled run still removes the fault.
+func holdAndRevert(ctx context.Context, containerID string, hold time.Duration, revert func(ctx context.Context, containerID string) error) error {
+	timer := time.NewTimer(hold)
+	defer timer.Stop()
 
-	return r.Injector.InjectNetworkLatency(ctx, containerID, delay)
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	return revert(context.WithoutCancel(ctx), containerID)
 }
 
 func (r *Runner) ExecuteExperiment(ctx context.Context, exp domainconfig.Experiment) ExperimentResult {
@@ -63,59 +81,123 @@ func (r *Runner) ExecuteExperiment(ctx context.Context, exp domainconfig.Experim
 		return res
 	}
 
-	target := strings.TrimSpace(exp.TargetContainer)
-	if target == "" {
-		res.Err = fmt.Errorf("target container is required")
+	target, err := r.resolveTarget(ctx, exp)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.TargetContainer = target
+
+	if r.Faults == nil {
+		res.Err = fmt.Errorf("fault registry is not configured")
+		return res
+	}
+	plugin, err := r.Faults.Lookup(exp.Fault.Type)
+	if err != nil {
+		res.Err = err
 		return res
 	}
 
-	switch exp.Fault.Type {
-	case "network-latency":
-		if r.Injector == nil {
-			res.Err = fmt.Errorf("fault injector is not configured")
+	// The config loader validated selector experiments without knowing the container, so
+	// checks against the target, such as a partition peer being the target itself, run
+	// again now that it is resolved.
+	if exp.Target != nil {
+		resolved := exp
+		resolved.TargetContainer = target
+		if err := plugin.Validate(resolved); err != nil {
+			res.Err = fmt.Errorf("target %s resolved by selector: %w", target, err)
 			return res
 		}
+	}
 
-		delay, err := time.ParseDuration(exp.Fault.Delay)
+	// Faults whose plugin returns as soon as they are applied stay in place until the
+	// runner reverts them; fault.duration decides when that happens.
+	var hold time.Duration
+	if plugin.RunnerReverts() && strings.TrimSpace(exp.Fault.Duration) != "" {
+		hold, err = time.ParseDuration(exp.Fault.Duration)
 		if err != nil {
-			res.Err = fmt.Errorf("parse network-latency delay %q: %w", exp.Fault.Delay, err)
+			res.Err = fmt.Errorf("parse duration %q: %w", exp.Fault.Duration, err)
 			return res
 		}
-
-		if err := r.Injector.InjectNetworkLatency(ctx, target, delay); err != nil {
-			res.Err = fmt.Errorf("inject network latency: %w", err)
+		if hold <= 0 {
+			res.Err = fault.ErrInvalidFaultDuration
 			return res
 		}
+	}
 
-		res.Message = fmt.Sprintf("applied %s network delay to %s", delay, target)
-	case "kill":
-		if r.Killer == nil {
-			res.Err = fmt.Errorf("container killer is not configured")
+	// The target is marked before injecting, so a panic rollback also covers a fault that was
+	// only partly applied when Inject failed or the process died.
+	if r.Tracker != nil {
+		r.Tracker.Mark(target)
+	}
+
+	res.InjectedAt = time.Now().UTC()
+	outcome, err := plugin.Inject(ctx, target, exp.Fault)
+	res.OOMKilled = outcome.OOMKilled
+	res.ConnectionsReset = outcome.ConnectionsReset
+	res.ProcessesKilled = outcome.ProcessesKilled
+	if err != nil {
+		res.Err = err
+		if revertErr := plugin.Revert(context.WithoutCancel(ctx), target); revertErr != nil {
+			res.Err = errors.Join(err, fmt.Errorf("revert %s: %w", exp.Fault.Type, revertErr))
 			return res
 		}
+		res.RevertedAt = time.Now().UTC()
+		return res
+	}
+	res.Message = plugin.Describe(target, exp.Fault, outcome)
 
-		signal := strings.TrimSpace(exp.Fault.Signal)
-		if err := r.Killer.KillContainer(ctx, target, signal); err != nil {
-			res.Err = fmt.Errorf("kill container: %w", err)
+	if !plugin.RunnerReverts() {
+		res.RevertedAt = time.Now().UTC()
+	}
+	if hold > 0 {
+		res.Message += fmt.Sprintf(" for %s", hold)
+		if err := holdAndRevert(ctx, target, hold, plugin.Revert); err != nil {
+			res.Err = fmt.Errorf("revert %s: %w", exp.Fault.Type, err)
 			return res
 		}
-
-		if signal == "" {
-			signal = "SIGKILL"
+		res.RevertedAt = time.Now().UTC()
+		if ctx.Err() != nil {
+			res.Message += " (reverted early, run was cancelled)"
 		}
-		res.Message = fmt.Sprintf("sent %s to %s", signal, target)
-	default:
-		res.Err = fmt.Errorf("unsupported fault type %q", exp.Fault.Type)
-		return res
 	}
 
-	if r.Tracker != nil {
-		r.Tracker.Mark(target)
+	return res
+}
+
+// resolveTarget returns the container an experiment hits. A target selector is resolved
+// against the running containers on every execution and picks one match at random, so
+// compose-generated names may change between runs.
+func (r *Runner) resolveTarget(ctx context.Context, exp domainconfig.Experiment) (string, error) {
+	if exp.Target == nil {
+		name := strings.TrimSpace(exp.TargetContainer)
+		if name == "" {
+			return "", fmt.Errorf("target container is required")
+		}
+		return name, nil
 	}
 
-	return res
+	if r.Targets == nil {
+		return "", fmt.Errorf("target lister is not configured")
+	}
+	selector, err := domaintarget.Compile(*exp.Target)
+	if err != nil {
+		return "", fmt.Errorf("parse target selector: %w", err)
+	}
+	containers, err := r.Targets.RunningContainers(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolve target %s: %w", selector, err)
+	}
+	matches := selector.Filter(containers)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("resolve target %s: %w", selector, domaintarget.ErrNoMatchingContainer)
+	}
+	return matches[rand.Intn(len(matches))].Name, nil
 }
 
+// RunOnce executes the experiments one after another. ExecuteExperiment returns only once a
+// fault's duration has passed and the fault is reverted, so durations add up: an experiment
+// starts after the previous one has ended, and faults of one run never overlap.
 func (r *Runner) RunOnce(ctx context.Context, cfg domainconfig.ChaosConfig) []ExperimentResult {
 	results := make([]ExperimentResult, 0, len(cfg.Experiments))
 	for _, exp := range cfg.Experiments {
@@ -0,0 +1,1239 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+	"github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	domaintarget "github.com/lekhanpro/chaos-dock/internal/domain/target"
+)
+
+// builtinRegistry returns the built-in registry backed by in, failing the test if it cannot
+// be built.
+func builtinRegistry(t *testing.T, in fault.Injectors) *fault.Registry {
+	t.Helper()
+	faults, err := fault.NewBuiltinRegistry(in)
+	if err != nil {
+		t.Fatalf("NewBuiltinRegistry returned error: %v", err)
+	}
+	return faults
+}
+
+type mockInjector struct {
+	lastContainerID string
+	lastLatency     fault.Latency
+	lastLoss        fault.Probability
+	lastBandwidth   fault.Bandwidth
+	lastNetem       fault.Netem
+	reverted        []string
+}
+
+func (m *mockInjector) InjectNetworkLatency(_ context.Context, containerID string, latency fault.Latency) error {
+	m.lastContainerID = containerID
+	m.lastLatency = latency
+	return nil
+}
+
+func (m *mockInjector) InjectPacketLoss(_ context.Context, containerID string, loss fault.Probability) error {
+	m.lastContainerID = containerID
+	m.lastLoss = loss
+	return nil
+}
+
+func (m *mockInjector) InjectBandwidthLimit(_ context.Context, containerID string, bandwidth fault.Bandwidth) error {
+	m.lastContainerID = containerID
+	m.lastBandwidth = bandwidth
+	return nil
+}
+
+func (m *mockInjector) InjectNetem(_ context.Context, containerID string, netem fault.Netem) error {
+	m.lastContainerID = containerID
+	m.lastNetem = netem
+	return nil
+}
+
+func (m *mockInjector) RevertNetworkLatency(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockTracker struct {
+	marked []string
+}
+
+func (m *mockTracker) Mark(containerID string) {
+	m.marked = append(m.marked, containerID)
+}
+
+func TestExecuteExperiment_NetworkLatencyWithJitter(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "wan-db",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:         "network-latency",
+			Delay:        "100ms",
+			Jitter:       "20ms",
+			Correlation:  "25%",
+			Distribution: "Normal",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+
+	want := fault.Latency{
+		Delay:        100 * time.Millisecond,
+		Jitter:       20 * time.Millisecond,
+		Correlation:  25,
+		Distribution: "normal",
+	}
+	if injector.lastLatency != want {
+		t.Fatalf("expected latency %#v, got %#v", want, injector.lastLatency)
+	}
+	if res.Message != "applied 100ms±20ms (25% correlation, normal distribution) network delay to postgres" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_NetworkLoss(t *testing.T) {
+	injector := &mockInjector{}
+	tracker := &mockTracker{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector}), Tracker: tracker}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "lossy-db",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:        "network-loss",
+			Loss:        "10%",
+			Correlation: "25%",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if injector.lastLoss != (fault.Probability{Percent: 10, Correlation: 25}) {
+		t.Fatalf("unexpected loss passed to injector: %#v", injector.lastLoss)
+	}
+	if res.Message != "applied 10% (25% correlation) packet loss to postgres" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+	if len(tracker.marked) != 1 || tracker.marked[0] != "postgres" {
+		t.Fatalf("expected postgres to be tracked, got %#v", tracker.marked)
+	}
+}
+
+func TestExecuteExperiment_NetworkLatencyIngress(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "postgres-slow-receive",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:      "network-latency",
+			Delay:     "200ms",
+			Direction: "Ingress",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if injector.lastNetem.Direction != "ingress" || injector.lastNetem.Latency == nil || injector.lastNetem.Latency.Delay != 200*time.Millisecond {
+		t.Fatalf("expected ingress netem with 200ms delay, got %#v", injector.lastNetem)
+	}
+	if injector.lastLatency != (fault.Latency{}) {
+		t.Fatalf("egress-only latency path should not be used, got %#v", injector.lastLatency)
+	}
+	if res.Message != "applied 200ms network delay to postgres on ingress" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_NetworkLatencyScopedToDestination(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "api-slow-db",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:  "network-latency",
+			Delay: "150ms",
+			To:    []string{"postgres"},
+			Ports: []int{5432},
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	to := injector.lastNetem.To
+	if to == nil || len(to.Hosts) != 1 || to.Hosts[0] != "postgres" || len(to.Ports) != 1 || to.Ports[0] != 5432 {
+		t.Fatalf("expected destination postgres:5432, got %#v", to)
+	}
+	if res.Message != "applied 150ms network delay to api for traffic to postgres on port(s) 5432" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_NetworkLossInvalidPercentage(t *testing.T) {
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: &mockInjector{}})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "lossy-db",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type: "network-loss",
+			Loss: "lots",
+		},
+	})
+	if res.Err == nil {
+		t.Fatalf("expected error for invalid loss percentage")
+	}
+}
+
+func TestExecuteExperiment_Bandwidth(t *testing.T) {
+	injector := &mockInjector{}
+	tracker := &mockTracker{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector}), Tracker: tracker}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "slow-uplink",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:  "bandwidth",
+			Rate:  "256kbit",
+			Burst: "32kb",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if injector.lastBandwidth != (fault.Bandwidth{Rate: 256_000, Burst: 32 * 1024}) {
+		t.Fatalf("unexpected bandwidth passed to injector: %#v", injector.lastBandwidth)
+	}
+	if len(tracker.marked) != 1 || tracker.marked[0] != "api" {
+		t.Fatalf("expected api to be tracked, got %#v", tracker.marked)
+	}
+}
+
+func TestExecuteExperiment_NetworkReorderCombinesDelay(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "reorder-api",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:        "network-reorder",
+			Reorder:     "25%",
+			Correlation: "50%",
+			Delay:       "10ms",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+
+	netem := injector.lastNetem
+	if netem.Latency == nil || netem.Latency.Delay != 10*time.Millisecond {
+		t.Fatalf("expected 10ms delay in netem, got %#v", netem.Latency)
+	}
+	if netem.Reorder == nil || *netem.Reorder != (fault.Probability{Percent: 25, Correlation: 50}) {
+		t.Fatalf("unexpected reorder probability %#v", netem.Reorder)
+	}
+	if res.Message != "applied 10ms delay, 25% (50% correlation) packet reordering to api" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_NetworkComposite(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "bad-wan",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type: "network",
+			Network: &domainconfig.NetworkImpairment{
+				Delay:     "100ms",
+				Jitter:    "20ms",
+				Loss:      "5%",
+				Duplicate: "1%",
+				Rate:      "1mbit",
+			},
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+
+	netem := injector.lastNetem
+	if netem.Latency == nil || netem.Loss == nil || netem.Duplicate == nil || netem.Rate != 1_000_000 {
+		t.Fatalf("expected delay, loss, duplicate and rate in netem, got %#v", netem)
+	}
+	if netem.Corrupt != nil || netem.Reorder != nil {
+		t.Fatalf("unexpected impairments in netem: %#v", netem)
+	}
+
+	want := "applied 100ms±20ms delay, 5% packet loss, 1% packet duplication, 1mbit rate limit to api"
+	if res.Message != want {
+		t.Fatalf("expected message %q, got %q", want, res.Message)
+	}
+}
+
+type mockPartitioner struct {
+	lastContainerID string
+	lastPeers       []string
+}
+
+func (m *mockPartitioner) PartitionNetwork(_ context.Context, containerID string, peers []string) error {
+	m.lastContainerID = containerID
+	m.lastPeers = peers
+	return nil
+}
+
+func (m *mockPartitioner) RevertNetworkPartition(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_NetworkPartition(t *testing.T) {
+	partitioner := &mockPartitioner{}
+	tracker := &mockTracker{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Partitioner: partitioner}), Tracker: tracker}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "split-api-db",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:  "network-partition",
+			Peers: []string{"postgres", "redis"},
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if partitioner.lastContainerID != "api" || len(partitioner.lastPeers) != 2 {
+		t.Fatalf("unexpected partition call: %q %#v", partitioner.lastContainerID, partitioner.lastPeers)
+	}
+	if res.Message != "partitioned api from postgres, redis" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+	if len(tracker.marked) != 1 || tracker.marked[0] != "api" {
+		t.Fatalf("expected api to be tracked, got %#v", tracker.marked)
+	}
+}
+
+func TestExecuteExperiment_NetworkPartitionRequiresPartitioner(t *testing.T) {
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "split-api-db",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:  "network-partition",
+			Peers: []string{"postgres"},
+		},
+	})
+	if res.Err == nil {
+		t.Fatalf("expected error without partitioner")
+	}
+}
+
+type mockBlackholer struct {
+	lastBlackhole fault.PortBlackhole
+}
+
+func (m *mockBlackholer) BlackholePorts(_ context.Context, _ string, blackhole fault.PortBlackhole) error {
+	m.lastBlackhole = blackhole
+	return nil
+}
+
+func (m *mockBlackholer) RevertPortBlackhole(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_PortBlackholeReject(t *testing.T) {
+	blackholer := &mockBlackholer{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Blackholer: blackholer})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "redis-refused",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:      "port-blackhole",
+			Ports:     []int{6379},
+			Action:    "reject",
+			Direction: "egress",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if !blackholer.lastBlackhole.Reject || blackholer.lastBlackhole.Direction != "egress" {
+		t.Fatalf("unexpected blackhole passed to injector: %#v", blackholer.lastBlackhole)
+	}
+	if res.Message != "rejected egress tcp traffic on port(s) 6379 in api" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockDNS struct {
+	lastFault fault.DNSFault
+}
+
+func (m *mockDNS) InjectDNSFault(_ context.Context, _ string, dnsFault fault.DNSFault) error {
+	m.lastFault = dnsFault
+	return nil
+}
+
+func (m *mockDNS) RevertDNSFault(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_DNSNXDomain(t *testing.T) {
+	dns := &mockDNS{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{DNS: dns})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "postgres-unresolvable",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "dns",
+			Mode:     "NXDOMAIN",
+			Domains:  []string{"postgres"},
+			Duration: "30s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if dns.lastFault.Mode != "nxdomain" || dns.lastFault.Duration != 30*time.Second {
+		t.Fatalf("unexpected dns fault passed to injector: %#v", dns.lastFault)
+	}
+	if res.Message != "answered dns lookups of postgres with NXDOMAIN in api for 30s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockCPUStressor struct {
+	lastStress fault.CPUStress
+}
+
+func (m *mockCPUStressor) StressCPU(_ context.Context, _ string, stress fault.CPUStress) error {
+	m.lastStress = stress
+	return nil
+}
+
+func (m *mockCPUStressor) RevertCPUStress(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_CPUStressDefaults(t *testing.T) {
+	cpu := &mockCPUStressor{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{CPU: cpu})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "api-starved",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "cpu-stress",
+			Duration: "45s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.CPUStress{Workers: 1, Load: 100, Duration: 45 * time.Second}
+	if cpu.lastStress != want {
+		t.Fatalf("expected %#v, got %#v", want, cpu.lastStress)
+	}
+	if res.Message != "ran 1 cpu worker(s) at 100% load in api for 45s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockMemoryStressor struct {
+	lastStress fault.MemoryStress
+	err        error
+}
+
+func (m *mockMemoryStressor) StressMemory(_ context.Context, _ string, stress fault.MemoryStress) error {
+	m.lastStress = stress
+	return m.err
+}
+
+func (m *mockMemoryStressor) RevertMemoryStress(_ context.Context, _ string) error {
+	return nil
+}
+
+type mockInspector struct {
+	oomKilled bool
+}
+
+func (m *mockInspector) OOMKilled(_ context.Context, _ string) (bool, error) {
+	return m.oomKilled, nil
+}
+
+func TestExecuteExperiment_MemoryStressAbsolute(t *testing.T) {
+	memory := &mockMemoryStressor{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Memory: memory, Inspector: &mockInspector{}})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "api-memory-pressure",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "memory-stress",
+			Size:     "256mb",
+			Duration: "30s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if memory.lastStress.Bytes != 256*1024*1024 || memory.lastStress.OOM {
+		t.Fatalf("unexpected memory stress passed to stressor: %#v", memory.lastStress)
+	}
+	if res.OOMKilled {
+		t.Fatalf("expected OOMKilled to be false")
+	}
+	if res.Message != "allocated 256MiB in api for 30s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_MemoryStressRecordsOOMKilled(t *testing.T) {
+	memory := &mockMemoryStressor{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Memory: memory, Inspector: &mockInspector{oomKilled: true}})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "api-oom",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "memory-stress",
+			OOM:      true,
+			Duration: "20s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if !memory.lastStress.OOM {
+		t.Fatalf("expected OOM mode to be passed to stressor")
+	}
+	if !res.OOMKilled {
+		t.Fatalf("expected OOMKilled to be recorded")
+	}
+	if res.Message != "pushed api past its memory limit for 20s (docker reported OOMKilled)" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_MemoryStressReportsUncrossedLimit(t *testing.T) {
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Memory: &mockMemoryStressor{}, Inspector: &mockInspector{}})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "api-oom",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "memory-stress", OOM: true, Duration: "20s"},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if res.Message != "allocated past the memory limit of api for 20s, but the limit was not crossed (docker reported no OOM kill)" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockIOThrottler struct {
+	lastThrottle fault.IOThrottle
+}
+
+func (m *mockIOThrottler) ThrottleIO(_ context.Context, _ string, throttle fault.IOThrottle) error {
+	m.lastThrottle = throttle
+	return nil
+}
+
+func (m *mockIOThrottler) RevertIOThrottle(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_IOThrottle(t *testing.T) {
+	throttler := &mockIOThrottler{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{IO: throttler})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "postgres-slow-disk",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:      "io-throttle",
+			Device:    "/dev/sda",
+			WriteBps:  "1mb",
+			WriteIops: 50,
+			Duration:  "1m",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.IOThrottle{Device: "/dev/sda", WriteBPS: 1024 * 1024, WriteIOPS: 50, Duration: time.Minute}
+	if throttler.lastThrottle != want {
+		t.Fatalf("expected %#v, got %#v", want, throttler.lastThrottle)
+	}
+	if res.Message != "throttled postgres on /dev/sda to 1MiB/s write, 50 write iops for 1m0s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockDiskFiller struct {
+	lastFill fault.DiskFill
+}
+
+func (m *mockDiskFiller) FillDisk(_ context.Context, _ string, fill fault.DiskFill) error {
+	m.lastFill = fill
+	return nil
+}
+
+func (m *mockDiskFiller) RevertDiskFill(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_DiskFillVolume(t *testing.T) {
+	filler := &mockDiskFiller{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Disk: filler})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "postgres-full-volume",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "disk-fill",
+			Volume:   "pgdata",
+			Size:     "95%",
+			Duration: "30s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.DiskFill{Volume: "pgdata", Percent: 95, Duration: 30 * time.Second}
+	if filler.lastFill != want {
+		t.Fatalf("expected %#v, got %#v", want, filler.lastFill)
+	}
+	if res.Message != "filled volume pgdata in postgres to 95% for 30s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockPauser struct {
+	lastTarget   string
+	lastDuration time.Duration
+}
+
+func (m *mockPauser) PauseContainer(_ context.Context, containerID string, duration time.Duration) error {
+	m.lastTarget = containerID
+	m.lastDuration = duration
+	return nil
+}
+
+func (m *mockPauser) RevertPause(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_Pause(t *testing.T) {
+	pauser := &mockPauser{}
+	tracker := &mockTracker{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Pauser: pauser}), Tracker: tracker}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "freeze-leader",
+		TargetContainer: "etcd-1",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "pause",
+			Duration: "15s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if pauser.lastTarget != "etcd-1" || pauser.lastDuration != 15*time.Second {
+		t.Fatalf("unexpected pause call %q for %s", pauser.lastTarget, pauser.lastDuration)
+	}
+	if res.Message != "paused etcd-1 for 15s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+	if len(tracker.marked) != 1 {
+		t.Fatalf("expected paused target to be tracked")
+	}
+}
+
+type mockCycler struct {
+	lastDowntime time.Duration
+	lastLoop     fault.RestartLoop
+}
+
+func (m *mockCycler) StopContainer(_ context.Context, _ string, downtime time.Duration) error {
+	m.lastDowntime = downtime
+	return nil
+}
+
+func (m *mockCycler) RestartContainer(_ context.Context, _ string, loop fault.RestartLoop) error {
+	m.lastLoop = loop
+	return nil
+}
+
+func TestExecuteExperiment_RestartLoop(t *testing.T) {
+	cycler := &mockCycler{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Cycler: cycler})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "flapping-api",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "restart-loop",
+			Count:    3,
+			Interval: "10s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if cycler.lastLoop != (fault.RestartLoop{Count: 3, Interval: 10 * time.Second}) {
+		t.Fatalf("unexpected restart loop %#v", cycler.lastLoop)
+	}
+	if res.Message != "restarted api 3 time(s) every 10s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockDisconnector struct {
+	lastFault fault.NetworkDisconnect
+}
+
+func (m *mockDisconnector) DisconnectNetwork(_ context.Context, _ string, f fault.NetworkDisconnect) error {
+	m.lastFault = f
+	return nil
+}
+
+func (m *mockDisconnector) RevertNetworkDisconnect(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_NetworkDisconnect(t *testing.T) {
+	disconnector := &mockDisconnector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Disconnector: disconnector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "db-off-backend",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:          "network-disconnect",
+			DockerNetwork: "backend",
+			Duration:      "45s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.NetworkDisconnect{Network: "backend", Duration: 45 * time.Second}
+	if disconnector.lastFault != want {
+		t.Fatalf("expected %#v, got %#v", want, disconnector.lastFault)
+	}
+	if res.Message != "disconnected postgres from network backend for 45s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockProcessKiller struct {
+	lastKill fault.ProcessKill
+}
+
+func (m *mockProcessKiller) KillProcesses(_ context.Context, _ string, kill fault.ProcessKill) (int, error) {
+	m.lastKill = kill
+	return 2, nil
+}
+
+func TestExecuteExperiment_ProcessKill(t *testing.T) {
+	killer := &mockProcessKiller{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{ProcessKiller: killer})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "kill-celery-worker",
+		TargetContainer: "worker",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:    "process-kill",
+			Process: "python3",
+			Cmdline: "celery .*worker",
+			Signal:  "SIGTERM",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.ProcessKill{Name: "python3", Cmdline: "celery .*worker", Signal: "SIGTERM"}
+	if killer.lastKill != want {
+		t.Fatalf("expected %#v, got %#v", want, killer.lastKill)
+	}
+	if res.Message != `sent SIGTERM to 2 process(es) named python3 and matching "celery .*worker" in worker` {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+	if res.ProcessesKilled != 2 {
+		t.Fatalf("expected 2 killed processes in the result, got %d", res.ProcessesKilled)
+	}
+}
+
+type mockClockSkewer struct {
+	lastSkew fault.ClockSkew
+}
+
+func (m *mockClockSkewer) SkewClock(_ context.Context, _ string, skew fault.ClockSkew) error {
+	m.lastSkew = skew
+	return nil
+}
+
+func (m *mockClockSkewer) RevertClockSkew(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_ClockSkewShowsOffset(t *testing.T) {
+	clock := &mockClockSkewer{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Clock: clock})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "expire-tokens",
+		TargetContainer: "auth",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "clock-skew",
+			Offset:   "-2h",
+			Duration: "5m",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.ClockSkew{Offset: -2 * time.Hour, Duration: 5 * time.Minute}
+	if clock.lastSkew != want {
+		t.Fatalf("expected %#v, got %#v", want, clock.lastSkew)
+	}
+	if res.Message != "skewed the clock of auth by -2h0m0s for 5m0s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockHTTPFaultInjector struct {
+	lastFault fault.HTTPFault
+}
+
+func (m *mockHTTPFaultInjector) InjectHTTPFault(_ context.Context, _ string, f fault.HTTPFault) error {
+	m.lastFault = f
+	return nil
+}
+
+func (m *mockHTTPFaultInjector) RevertHTTPFault(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_HTTPAbort(t *testing.T) {
+	injector := &mockHTTPFaultInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{HTTP: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "orders-unavailable",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:       "http",
+			Ports:      []int{8080},
+			Routes:     []domainconfig.HTTPRoute{{Method: "post", Path: "/orders*"}},
+			Abort:      503,
+			Percentage: "10%",
+			Duration:   "5m",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if len(injector.lastFault.Routes) != 1 || injector.lastFault.Routes[0] != (fault.HTTPRoute{Method: "POST", Path: "/orders*"}) {
+		t.Fatalf("unexpected routes %#v", injector.lastFault.Routes)
+	}
+	if injector.lastFault.Percent != 10 || injector.lastFault.AbortStatus != 503 {
+		t.Fatalf("unexpected fault %#v", injector.lastFault)
+	}
+	if res.Message != "returned 503 for 10% of POST /orders* on api:8080 for 5m0s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+type mockResetter struct {
+	lastReset fault.TCPReset
+	count     int
+	err       error
+}
+
+func (m *mockResetter) ResetConnections(_ context.Context, _ string, reset fault.TCPReset) (int, error) {
+	m.lastReset = reset
+	return m.count, m.err
+}
+
+func (m *mockResetter) RevertTCPReset(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_TCPReset(t *testing.T) {
+	resetter := &mockResetter{count: 12}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Resetter: resetter})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "db-pool-recovery",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:     "tcp-reset",
+			Peers:    []string{"postgres"},
+			Ports:    []int{5432},
+			Rate:     "30/m",
+			Duration: "30s",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if resetter.lastReset.Rate != 0.5 || resetter.lastReset.Duration != 30*time.Second {
+		t.Fatalf("unexpected reset %#v", resetter.lastReset)
+	}
+	if res.ConnectionsReset != 12 {
+		t.Fatalf("expected 12 connections reset in result, got %d", res.ConnectionsReset)
+	}
+	if res.Message != "reset 12 established connection(s) of api with postgres on port 5432 at 0.5/s for 30s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_TCPResetKeepsCountOnError(t *testing.T) {
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Resetter: &mockResetter{count: 3, err: fault.ErrSSCommandFailed}})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "db-pool-recovery",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "tcp-reset", Ports: []int{5432}, Duration: "30s"},
+	})
+	if !errors.Is(res.Err, fault.ErrSSCommandFailed) {
+		t.Fatalf("expected ErrSSCommandFailed, got %v", res.Err)
+	}
+	if res.ConnectionsReset != 3 {
+		t.Fatalf("expected resets before the failure to be reported, got %d", res.ConnectionsReset)
+	}
+}
+
+type mockExhauster struct {
+	lastExhaustion fault.ResourceExhaustion
+}
+
+func (m *mockExhauster) ExhaustResource(_ context.Context, _ string, exhaustion fault.ResourceExhaustion) error {
+	m.lastExhaustion = exhaustion
+	return nil
+}
+
+func (m *mockExhauster) RevertResourceExhaustion(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestExecuteExperiment_ResourceExhaustion(t *testing.T) {
+	exhauster := &mockExhauster{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Exhauster: exhauster})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "fork-bomb",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault: domainconfig.Fault{
+			Type:       "resource-exhaustion",
+			Resource:   "pids",
+			Percentage: "90%",
+			Duration:   "1m",
+		},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	want := fault.ResourceExhaustion{Resource: "pids", Percent: 90, Duration: time.Minute}
+	if exhauster.lastExhaustion != want {
+		t.Fatalf("expected %#v, got %#v", want, exhauster.lastExhaustion)
+	}
+	if res.Message != "filled 90% of the pids limit of api for 1m0s" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_NetworkLatencyRevertsAfterDuration(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "slow-db",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "network-latency", Delay: "100ms", Duration: "20ms"},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if len(injector.reverted) != 1 || injector.reverted[0] != "postgres" {
+		t.Fatalf("expected one revert for postgres, got %#v", injector.reverted)
+	}
+	if res.InjectedAt.IsZero() || res.RevertedAt.Sub(res.InjectedAt) < 20*time.Millisecond {
+		t.Fatalf("expected revert at least 20ms after injection, got %s -> %s", res.InjectedAt, res.RevertedAt)
+	}
+	if res.Message != "applied 100ms network delay to postgres for 20ms" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+}
+
+func TestExecuteExperiment_RevertsWhenCancelled(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	res := runner.ExecuteExperiment(ctx, domainconfig.Experiment{
+		Name:            "slow-db",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "network-loss", Loss: "10%", Duration: "1h"},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if len(injector.reverted) != 1 {
+		t.Fatalf("expected the fault to be reverted on cancellation, got %#v", injector.reverted)
+	}
+	if res.RevertedAt.IsZero() {
+		t.Fatalf("expected RevertedAt to be recorded")
+	}
+}
+
+func TestExecuteExperiment_NoDurationLeavesFaultInPlace(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{Injector: injector})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "slow-db",
+		TargetContainer: "postgres",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "network-latency", Delay: "100ms"},
+	})
+	if res.Err != nil {
+		t.Fatalf("ExecuteExperiment returned error: %v", res.Err)
+	}
+	if len(injector.reverted) != 0 || res.InjectedAt.IsZero() || !res.RevertedAt.IsZero() {
+		t.Fatalf("expected an injection without a scheduled revert, got %#v (%s, %s)", injector.reverted, res.InjectedAt, res.RevertedAt)
+	}
+}
+
+type customPlugin struct {
+	injected  []string
+	reverted  []string
+	injectErr error
+}
+
+func (c *customPlugin) Types() []string { return []string{"custom"} }
+
+func (c *customPlugin) RunnerReverts() bool { return false }
+
+func (c *customPlugin) Validate(domainconfig.Experiment) error { return nil }
+
+func (c *customPlugin) Inject(_ context.Context, containerID string, _ domainconfig.Fault) (fault.Outcome, error) {
+	c.injected = append(c.injected, containerID)
+	return fault.Outcome{}, c.injectErr
+}
+
+func (c *customPlugin) Revert(_ context.Context, containerID string) error {
+	c.reverted = append(c.reverted, containerID)
+	return nil
+}
+
+func (c *customPlugin) Describe(containerID string, _ domainconfig.Fault, _ fault.Outcome) string {
+	return "customised " + containerID
+}
+
+func TestExecuteExperiment_UnknownFaultType(t *testing.T) {
+	runner := &Runner{Faults: builtinRegistry(t, fault.Injectors{})}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "mystery",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "meteor-strike"},
+	})
+	if !errors.Is(res.Err, fault.ErrUnknownFaultType) {
+		t.Fatalf("expected ErrUnknownFaultType, got %v", res.Err)
+	}
+}
+
+func TestExecuteExperiment_RegisteredPlugin(t *testing.T) {
+	plugin := &customPlugin{}
+	faults := builtinRegistry(t, fault.Injectors{})
+	if err := faults.Register(plugin); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	runner := &Runner{Faults: faults}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "custom",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "custom"},
+	})
+	if res.Err != nil {
+		t.Fatalf("expected no error, got %v", res.Err)
+	}
+	if len(plugin.injected) != 1 || plugin.injected[0] != "api" {
+		t.Fatalf("expected custom plugin to inject api, got %#v", plugin.injected)
+	}
+	if res.Message != "customised api" {
+		t.Fatalf("unexpected message %q", res.Message)
+	}
+	if res.InjectedAt.IsZero() || res.RevertedAt.Before(res.InjectedAt) {
+		t.Fatalf("expected a self-reverting fault to record both times, got %s -> %s", res.InjectedAt, res.RevertedAt)
+	}
+}
+
+func TestExecuteExperiment_FailedInjectIsMarkedAndReverted(t *testing.T) {
+	injectErr := errors.New("second rule failed")
+	plugin := &customPlugin{injectErr: injectErr}
+	faults := builtinRegistry(t, fault.Injectors{})
+	if err := faults.Register(plugin); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+	tracker := &mockTracker{}
+	runner := &Runner{Faults: faults, Tracker: tracker}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:            "custom",
+		TargetContainer: "api",
+		Enabled:         true,
+		Fault:           domainconfig.Fault{Type: "custom"},
+	})
+	if !errors.Is(res.Err, injectErr) {
+		t.Fatalf("expected the inject error, got %v", res.Err)
+	}
+	if len(tracker.marked) != 1 || tracker.marked[0] != "api" {
+		t.Fatalf("expected api to be tracked despite the failure, got %#v", tracker.marked)
+	}
+	if len(plugin.reverted) != 1 || plugin.reverted[0] != "api" {
+		t.Fatalf("expected the partial fault to be reverted, got %#v", plugin.reverted)
+	}
+	if res.InjectedAt.IsZero() || res.RevertedAt.IsZero() {
+		t.Fatalf("expected both times to be recorded, got %s -> %s", res.InjectedAt, res.RevertedAt)
+	}
+}
+
+type mockLister struct {
+	containers []domaintarget.Container
+}
+
+func (m *mockLister) RunningContainers(_ context.Context) ([]domaintarget.Container, error) {
+	return m.containers, nil
+}
+
+func TestExecuteExperiment_ResolvesTargetSelector(t *testing.T) {
+	injector := &mockInjector{}
+	tracker := &mockTracker{}
+	runner := &Runner{
+		Faults: builtinRegistry(t, fault.Injectors{Injector: injector}),
+		Targets: &mockLister{containers: []domaintarget.Container{
+			{ID: "a1", Name: "shop-api-7", Image: "ghcr.io/acme/api:1.4", Labels: map[string]string{"tier": "backend"}},
+			{ID: "d1", Name: "shop-db-1", Image: "postgres:16", Labels: map[string]string{"tier": "data"}},
+		}},
+		Tracker: tracker,
+	}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:    "backend-latency",
+		Target:  &domainconfig.TargetSelector{Labels: map[string]string{"tier": "backend"}},
+		Enabled: true,
+		Fault:   domainconfig.Fault{Type: "network-latency", Delay: "200ms"},
+	})
+	if res.Err != nil {
+		t.Fatalf("expected no error, got %v", res.Err)
+	}
+	if res.TargetContainer != "shop-api-7" || injector.lastContainerID != "shop-api-7" {
+		t.Fatalf("expected shop-api-7 to be targeted, got result %q and injection %q", res.TargetContainer, injector.lastContainerID)
+	}
+	if len(tracker.marked) != 1 || tracker.marked[0] != "shop-api-7" {
+		t.Fatalf("expected shop-api-7 to be tracked, got %#v", tracker.marked)
+	}
+}
+
+func TestExecuteExperiment_TargetSelectorWithoutMatch(t *testing.T) {
+	injector := &mockInjector{}
+	runner := &Runner{
+		Faults:  builtinRegistry(t, fault.Injectors{Injector: injector}),
+		Targets: &mockLister{containers: []domaintarget.Container{{ID: "d1", Name: "shop-db-1", Image: "postgres:16"}}},
+	}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:    "backend-latency",
+		Target:  &domainconfig.TargetSelector{Service: "api"},
+		Enabled: true,
+		Fault:   domainconfig.Fault{Type: "network-latency", Delay: "200ms"},
+	})
+	if !errors.Is(res.Err, domaintarget.ErrNoMatchingContainer) {
+		t.Fatalf("expected ErrNoMatchingContainer, got %v", res.Err)
+	}
+	if injector.lastContainerID != "" {
+		t.Fatalf("expected no injection, got %q", injector.lastContainerID)
+	}
+}
+
+func TestExecuteExperiment_SelectorResolvingToPartitionPeer(t *testing.T) {
+	partitioner := &mockPartitioner{}
+	runner := &Runner{
+		Faults:  builtinRegistry(t, fault.Injectors{Partitioner: partitioner}),
+		Targets: &mockLister{containers: []domaintarget.Container{{ID: "d1", Name: "shop-db-1", Image: "postgres:16"}}},
+	}
+
+	res := runner.ExecuteExperiment(context.Background(), domainconfig.Experiment{
+		Name:    "split-db",
+		Target:  &domainconfig.TargetSelector{Image: "postgres:*"},
+		Enabled: true,
+		Fault:   domainconfig.Fault{Type: "network-partition", Peers: []string{"shop-db-1"}},
+	})
+	if res.Err == nil {
+		t.Fatalf("expected error when the selector resolves to a peer")
+	}
+	if partitioner.lastContainerID != "" {
+		t.Fatalf("expected no partition, got %q", partitioner.lastContainerID)
+	}
+}
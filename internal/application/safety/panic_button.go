@@ -13,9 +13,15 @@ type ContainerRestarter interface {
 	Restart(ctx context.Context, containerID string) error
 }
 
+// ContainerStateReader reports whether a container is running.
+type ContainerStateReader interface {
+	ContainerRunning(ctx context.Context, containerID string) (bool, error)
+}
+
 type PanicButton struct {
-	Injector  fault.FaultInjector
+	Faults    *fault.Registry
 	Restarter ContainerRestarter
+	States    ContainerStateReader // optional, every target is treated as running without it
 	Registry  *TargetRegistry
 }
 
@@ -23,7 +29,10 @@ func (p *PanicButton) TriggerAll(ctx context.Context) error {
 	return p.Trigger(ctx, nil)
 }
 
-// Trigger attempts best-effort rollback of network faults and restarts targets.
+// Trigger attempts best-effort rollback of every registered fault plugin and restarts
+// targets. Plugins are reverted in registration order, which unpauses targets first. Each
+// target's state is resolved once; stopped targets are restarted before the rollback, and
+// targets that cannot be resolved are reported once and skipped.
 func (p *PanicButton) Trigger(ctx context.Context, containerIDs []string) error {
 	containerIDs = normalizeTargets(containerIDs)
 	if len(containerIDs) == 0 && p.Registry != nil {
@@ -33,13 +42,38 @@ func (p *PanicButton) Trigger(ctx context.Context, containerIDs []string) error
 	var errs []error
 
 	for _, id := range containerIDs {
-		if p.Injector != nil {
-			if err := p.Injector.RevertNetworkLatency(ctx, id); err != nil {
-				errs = append(errs, fmt.Errorf("revert latency on %s: %w", id, err))
+		running := true
+		if p.States != nil {
+			var err error
+			running, err = p.States.ContainerRunning(ctx, id)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("resolve %s: %w", id, err))
+				continue
+			}
+		}
+
+		// A stopped target, such as one left down by a stop fault, has no processes or network
+		// namespace to revert in. It is started first, so faults that outlive its processes,
+		// like disk fill ballast, are still removed, and without a restarter it is skipped.
+		if !running {
+			if p.Restarter == nil {
+				continue
+			}
+			if err := p.Restarter.Restart(ctx, id); err != nil {
+				errs = append(errs, fmt.Errorf("restart %s: %w", id, err))
+				continue
+			}
+		}
+
+		if p.Faults != nil {
+			for _, plugin := range p.Faults.Plugins() {
+				if err := plugin.Revert(ctx, id); err != nil {
+					errs = append(errs, fmt.Errorf("revert %s on %s: %w", strings.Join(plugin.Types(), ","), id, err))
+				}
 			}
 		}
 
-		if p.Restarter != nil {
+		if running && p.Restarter != nil {
 			if err := p.Restarter.Restart(ctx, id); err != nil {
 				errs = append(errs, fmt.Errorf("restart %s: %w", id, err))
 			}
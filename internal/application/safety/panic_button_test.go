@@ -2,15 +2,42 @@ package safety
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/lekhanpro/chaos-dock/internal/domain/fault"
 )
 
+// builtinRegistry returns the built-in registry backed by in, failing the test if it cannot
+// be built.
+func builtinRegistry(t *testing.T, in fault.Injectors) *fault.Registry {
+	t.Helper()
+	faults, err := fault.NewBuiltinRegistry(in)
+	if err != nil {
+		t.Fatalf("NewBuiltinRegistry returned error: %v", err)
+	}
+	return faults
+}
+
 type mockInjector struct {
 	reverted []string
 }
 
-func (m *mockInjector) InjectNetworkLatency(_ context.Context, _ string, _ time.Duration) error {
+func (m *mockInjector) InjectNetworkLatency(_ context.Context, _ string, _ fault.Latency) error {
+	return nil
+}
+
+func (m *mockInjector) InjectPacketLoss(_ context.Context, _ string, _ fault.Probability) error {
+	return nil
+}
+
+func (m *mockInjector) InjectBandwidthLimit(_ context.Context, _ string, _ fault.Bandwidth) error {
+	return nil
+}
+
+func (m *mockInjector) InjectNetem(_ context.Context, _ string, _ fault.Netem) error {
 	return nil
 }
 
@@ -19,6 +46,171 @@ func (m *mockInjector) RevertNetworkLatency(_ context.Context, containerID strin
 	return nil
 }
 
+type mockPartitioner struct {
+	reverted []string
+}
+
+func (m *mockPartitioner) PartitionNetwork(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+
+func (m *mockPartitioner) RevertNetworkPartition(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockBlackholer struct {
+	reverted []string
+}
+
+func (m *mockBlackholer) BlackholePorts(_ context.Context, _ string, _ fault.PortBlackhole) error {
+	return nil
+}
+
+func (m *mockBlackholer) RevertPortBlackhole(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockDNS struct {
+	reverted []string
+}
+
+func (m *mockDNS) InjectDNSFault(_ context.Context, _ string, _ fault.DNSFault) error {
+	return nil
+}
+
+func (m *mockDNS) RevertDNSFault(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockCPUStressor struct {
+	reverted []string
+}
+
+func (m *mockCPUStressor) StressCPU(_ context.Context, _ string, _ fault.CPUStress) error {
+	return nil
+}
+
+func (m *mockCPUStressor) RevertCPUStress(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockMemoryStressor struct {
+	reverted []string
+}
+
+func (m *mockMemoryStressor) StressMemory(_ context.Context, _ string, _ fault.MemoryStress) error {
+	return nil
+}
+
+func (m *mockMemoryStressor) RevertMemoryStress(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockIOThrottler struct {
+	reverted []string
+}
+
+func (m *mockIOThrottler) ThrottleIO(_ context.Context, _ string, _ fault.IOThrottle) error {
+	return nil
+}
+
+func (m *mockIOThrottler) RevertIOThrottle(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockDiskFiller struct {
+	reverted []string
+}
+
+func (m *mockDiskFiller) FillDisk(_ context.Context, _ string, _ fault.DiskFill) error {
+	return nil
+}
+
+func (m *mockDiskFiller) RevertDiskFill(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockDisconnector struct {
+	reverted []string
+}
+
+func (m *mockDisconnector) DisconnectNetwork(_ context.Context, _ string, _ fault.NetworkDisconnect) error {
+	return nil
+}
+
+func (m *mockDisconnector) RevertNetworkDisconnect(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockHTTPFaultInjector struct {
+	reverted []string
+}
+
+func (m *mockHTTPFaultInjector) InjectHTTPFault(_ context.Context, _ string, _ fault.HTTPFault) error {
+	return nil
+}
+
+func (m *mockHTTPFaultInjector) RevertHTTPFault(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockExhauster struct {
+	reverted []string
+}
+
+func (m *mockExhauster) ExhaustResource(_ context.Context, _ string, _ fault.ResourceExhaustion) error {
+	return nil
+}
+
+func (m *mockExhauster) RevertResourceExhaustion(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockResetter struct {
+	reverted []string
+}
+
+func (m *mockResetter) ResetConnections(_ context.Context, _ string, _ fault.TCPReset) (int, error) {
+	return 0, nil
+}
+
+func (m *mockResetter) RevertTCPReset(_ context.Context, containerID string) error {
+	m.reverted = append(m.reverted, containerID)
+	return nil
+}
+
+type mockPauser struct {
+	calls *[]string
+}
+
+func (m *mockPauser) PauseContainer(_ context.Context, _ string, _ time.Duration) error {
+	return nil
+}
+
+func (m *mockPauser) RevertPause(_ context.Context, containerID string) error {
+	*m.calls = append(*m.calls, "unpause "+containerID)
+	return nil
+}
+
+type orderedRestarter struct {
+	calls *[]string
+}
+
+func (m *orderedRestarter) Restart(_ context.Context, containerID string) error {
+	*m.calls = append(*m.calls, "restart "+containerID)
+	return nil
+}
+
 type mockRestarter struct {
 	restarted []string
 }
@@ -37,7 +229,7 @@ func TestPanicButton_TriggerAllUsesRegistry(t *testing.T) {
 	restarter := &mockRestarter{}
 
 	button := &PanicButton{
-		Injector:  injector,
+		Faults:    builtinRegistry(t, fault.Injectors{Injector: injector}),
 		Restarter: restarter,
 		Registry:  registry,
 	}
@@ -55,3 +247,139 @@ func TestPanicButton_TriggerAllUsesRegistry(t *testing.T) {
 		t.Fatalf("expected registry reset after panic trigger")
 	}
 }
+
+func TestPanicButton_TriggerRevertsPacketFilters(t *testing.T) {
+	partitioner := &mockPartitioner{}
+	blackholer := &mockBlackholer{}
+	dns := &mockDNS{}
+	cpu := &mockCPUStressor{}
+	memory := &mockMemoryStressor{}
+	io := &mockIOThrottler{}
+	disk := &mockDiskFiller{}
+	disconnector := &mockDisconnector{}
+	httpFaults := &mockHTTPFaultInjector{}
+	resetter := &mockResetter{}
+	exhauster := &mockExhauster{}
+	restarter := &mockRestarter{}
+
+	button := &PanicButton{
+		Faults: builtinRegistry(t, fault.Injectors{
+			Partitioner:  partitioner,
+			Blackholer:   blackholer,
+			DNS:          dns,
+			CPU:          cpu,
+			Memory:       memory,
+			IO:           io,
+			Disk:         disk,
+			Disconnector: disconnector,
+			HTTP:         httpFaults,
+			Resetter:     resetter,
+			Exhauster:    exhauster,
+		}),
+		Restarter: restarter,
+	}
+
+	if err := button.Trigger(context.Background(), []string{"api", "api", " "}); err != nil {
+		t.Fatalf("Trigger returned error: %v", err)
+	}
+	if len(partitioner.reverted) != 1 || partitioner.reverted[0] != "api" {
+		t.Fatalf("expected one partition revert for api, got %#v", partitioner.reverted)
+	}
+	if len(blackholer.reverted) != 1 {
+		t.Fatalf("expected one port blackhole revert, got %#v", blackholer.reverted)
+	}
+	if len(dns.reverted) != 1 {
+		t.Fatalf("expected one dns fault revert, got %#v", dns.reverted)
+	}
+	if len(cpu.reverted) != 1 {
+		t.Fatalf("expected one cpu stress revert, got %#v", cpu.reverted)
+	}
+	if len(memory.reverted) != 1 {
+		t.Fatalf("expected one memory stress revert, got %#v", memory.reverted)
+	}
+	if len(io.reverted) != 1 {
+		t.Fatalf("expected one io throttle revert, got %#v", io.reverted)
+	}
+	if len(disk.reverted) != 1 {
+		t.Fatalf("expected one disk fill revert, got %#v", disk.reverted)
+	}
+	if len(disconnector.reverted) != 1 {
+		t.Fatalf("expected one network reconnect, got %#v", disconnector.reverted)
+	}
+	if len(httpFaults.reverted) != 1 {
+		t.Fatalf("expected one http fault revert, got %#v", httpFaults.reverted)
+	}
+	if len(resetter.reverted) != 1 {
+		t.Fatalf("expected one tcp reset revert, got %#v", resetter.reverted)
+	}
+	if len(exhauster.reverted) != 1 {
+		t.Fatalf("expected one resource exhaustion revert, got %#v", exhauster.reverted)
+	}
+	if len(restarter.restarted) != 1 {
+		t.Fatalf("expected 1 restart call, got %d", len(restarter.restarted))
+	}
+}
+
+func TestPanicButton_TriggerUnpausesBeforeRestart(t *testing.T) {
+	var calls []string
+	button := &PanicButton{
+		Faults:    builtinRegistry(t, fault.Injectors{Pauser: &mockPauser{calls: &calls}}),
+		Restarter: &orderedRestarter{calls: &calls},
+	}
+
+	if err := button.Trigger(context.Background(), []string{"db"}); err != nil {
+		t.Fatalf("Trigger returned error: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "unpause db" || calls[1] != "restart db" {
+		t.Fatalf("expected unpause before restart, got %#v", calls)
+	}
+}
+
+type mockStates struct {
+	running map[string]bool
+}
+
+func (m *mockStates) ContainerRunning(_ context.Context, containerID string) (bool, error) {
+	running, ok := m.running[containerID]
+	if !ok {
+		return false, errors.New("no such container")
+	}
+	return running, nil
+}
+
+func TestPanicButton_TriggerStartsStoppedTargetBeforeReverting(t *testing.T) {
+	var calls []string
+	button := &PanicButton{
+		Faults:    builtinRegistry(t, fault.Injectors{Pauser: &mockPauser{calls: &calls}}),
+		Restarter: &orderedRestarter{calls: &calls},
+		States:    &mockStates{running: map[string]bool{"db": false}},
+	}
+
+	if err := button.Trigger(context.Background(), []string{"db"}); err != nil {
+		t.Fatalf("Trigger returned error: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "restart db" || calls[1] != "unpause db" {
+		t.Fatalf("expected one restart before the rollback, got %#v", calls)
+	}
+}
+
+func TestPanicButton_TriggerReportsUnresolvedTargetOnce(t *testing.T) {
+	injector := &mockInjector{}
+	restarter := &mockRestarter{}
+	button := &PanicButton{
+		Faults:    builtinRegistry(t, fault.Injectors{Injector: injector}),
+		Restarter: restarter,
+		States:    &mockStates{running: map[string]bool{"api": true}},
+	}
+
+	err := button.Trigger(context.Background(), []string{"gone", "api"})
+	if err == nil || strings.Count(err.Error(), "\n") != 0 || !strings.Contains(err.Error(), "resolve gone") {
+		t.Fatalf("expected a single error for gone, got %v", err)
+	}
+	if len(injector.reverted) != 1 || injector.reverted[0] != "api" {
+		t.Fatalf("expected only api to be reverted, got %#v", injector.reverted)
+	}
+	if len(restarter.restarted) != 1 || restarter.restarted[0] != "api" {
+		t.Fatalf("expected only api to be restarted, got %#v", restarter.restarted)
+	}
+}
@@ -5,17 +5,102 @@ type ChaosConfig struct {
 }
 
 type Experiment struct {
-	Name            string   `yaml:"name"`
-	TargetContainer string   `yaml:"targetContainer"`
-	Enabled         bool     `yaml:"enabled"`
-	Fault           Fault    `yaml:"fault"`
-	Schedule        Schedule `yaml:"schedule"`
+	Name            string          `yaml:"name"`
+	TargetContainer string          `yaml:"targetContainer,omitempty"`
+	Target          *TargetSelector `yaml:"target,omitempty"` // resolved to one running container per execution, instead of targetContainer
+	Enabled         bool            `yaml:"enabled"`
+	Fault           Fault           `yaml:"fault"`
+	Schedule        Schedule        `yaml:"schedule"`
+}
+
+// TargetSelector matches running containers. Every set matcher must match.
+type TargetSelector struct {
+	Labels  map[string]string `yaml:"labels,omitempty"`  // e.g. {tier: backend}; an empty value only requires the label
+	Project string            `yaml:"project,omitempty"` // compose project, the com.docker.compose.project label
+	Service string            `yaml:"service,omitempty"` // compose service, the com.docker.compose.service label
+	Image   string            `yaml:"image,omitempty"`   // glob on the image reference, e.g. postgres:* or ghcr.io/acme/*
+	Name    string            `yaml:"name,omitempty"`    // regular expression on the container name, e.g. ^shop-api-\d+$
 }
 
 type Fault struct {
-	Type   string `yaml:"type"`             // network-latency | kill
-	Delay  string `yaml:"delay,omitempty"`  // e.g. 500ms
-	Signal string `yaml:"signal,omitempty"` // e.g. SIGKILL
+	// network | network-latency | network-loss | network-corrupt | network-duplicate | network-reorder |
+	// network-partition | network-disconnect | port-blackhole | bandwidth | dns | cpu-stress | memory-stress |
+	// io-throttle | disk-fill | pause | stop | restart-loop | kill | process-kill | clock-skew | http | tcp-reset |
+	// resource-exhaustion
+	Type         string `yaml:"type"`
+	Delay        string `yaml:"delay,omitempty"`        // e.g. 500ms
+	Jitter       string `yaml:"jitter,omitempty"`       // e.g. 50ms
+	Distribution string `yaml:"distribution,omitempty"` // normal | pareto | paretonormal
+	Loss         string `yaml:"loss,omitempty"`         // e.g. 10%
+	Corrupt      string `yaml:"corrupt,omitempty"`      // e.g. 0.1%
+	Duplicate    string `yaml:"duplicate,omitempty"`    // e.g. 1%
+	Reorder      string `yaml:"reorder,omitempty"`      // e.g. 25%, requires delay
+	Correlation  string `yaml:"correlation,omitempty"`  // e.g. 25%
+	Rate         string `yaml:"rate,omitempty"`         // e.g. 256kbit, tcp-reset: e.g. 5/s, 30/m or 2/h
+	Burst        string `yaml:"burst,omitempty"`        // e.g. 32kb
+	Limit        string `yaml:"limit,omitempty"`        // e.g. 64kb
+	Signal       string `yaml:"signal,omitempty"`       // e.g. SIGKILL
+	Process      string `yaml:"process,omitempty"`      // process-kill: process name, e.g. nginx
+	Cmdline      string `yaml:"cmdline,omitempty"`      // process-kill: regular expression on the command line
+
+	Network *NetworkImpairment `yaml:"network,omitempty"` // used by type network
+	Peers   []string           `yaml:"peers,omitempty"`   // containers cut off by network-partition, tcp-reset: containers, IPs or CIDRs whose connections are reset
+	To      []string           `yaml:"to,omitempty"`      // netem faults: only impair traffic to these containers, IPs or CIDRs
+
+	Ports     []int    `yaml:"ports,omitempty"`     // e.g. [6379], destination ports for netem faults
+	Protocols []string `yaml:"protocols,omitempty"` // tcp | udp, defaults to tcp
+	Action    string   `yaml:"action,omitempty"`    // drop | reject, defaults to drop
+	Direction string   `yaml:"direction,omitempty"` // ingress | egress | both, port-blackhole defaults to both, shaping faults to egress
+
+	Mode     string   `yaml:"mode,omitempty"`     // dns: nxdomain | servfail | delay
+	Domains  []string `yaml:"domains,omitempty"`  // dns: affected names and their subdomains, all when empty
+	Duration string   `yaml:"duration,omitempty"` // e.g. 30s, how long the fault is held; experiments of a run execute in turn, so durations add up
+
+	Workers int    `yaml:"workers,omitempty"` // cpu-stress: busy workers, defaults to 1
+	Load    string `yaml:"load,omitempty"`    // cpu-stress: e.g. 80%, defaults to 100%
+
+	Size string `yaml:"size,omitempty"` // memory-stress and disk-fill: e.g. 256mb, or a percentage
+	OOM  bool   `yaml:"oom,omitempty"`  // memory-stress: allocate past the limit to trigger the OOM killer
+
+	Device    string `yaml:"device,omitempty"`    // io-throttle: block device, e.g. /dev/sda
+	ReadBps   string `yaml:"readBps,omitempty"`   // io-throttle: e.g. 1mb (per second)
+	WriteBps  string `yaml:"writeBps,omitempty"`  // io-throttle: e.g. 512kb (per second)
+	ReadIops  uint64 `yaml:"readIops,omitempty"`  // io-throttle
+	WriteIops uint64 `yaml:"writeIops,omitempty"` // io-throttle
+
+	Path   string `yaml:"path,omitempty"`   // disk-fill: directory inside the container
+	Volume string `yaml:"volume,omitempty"` // disk-fill: named volume, filled at its mount point
+
+	Count    int    `yaml:"count,omitempty"`    // restart-loop: number of restarts, resource-exhaustion: processes or sockets to add
+	Interval string `yaml:"interval,omitempty"` // restart-loop: e.g. 10s between restarts
+
+	DockerNetwork string `yaml:"dockerNetwork,omitempty"` // network-disconnect: Docker network to detach from
+
+	Offset string `yaml:"offset,omitempty"` // clock-skew: e.g. -2h or 90s
+
+	Resource string `yaml:"resource,omitempty"` // resource-exhaustion: pids | files
+
+	Routes     []HTTPRoute `yaml:"routes,omitempty"`     // http: requests to fault, all when empty
+	Abort      int         `yaml:"abort,omitempty"`      // http: status code returned instead of forwarding, e.g. 503
+	Percentage string      `yaml:"percentage,omitempty"` // http: share of matching requests, e.g. 10%, defaults to 100%; resource-exhaustion: share of the limit to reach
+}
+
+// HTTPRoute matches requests for the http fault. A path ending in "*" matches by prefix.
+type HTTPRoute struct {
+	Method string `yaml:"method,omitempty"` // e.g. POST, any method when empty
+	Path   string `yaml:"path,omitempty"`   // e.g. /orders or /orders/*, any path when empty
+}
+
+// NetworkImpairment combines netem impairments that are applied together in one qdisc.
+type NetworkImpairment struct {
+	Delay        string `yaml:"delay,omitempty"`        // e.g. 100ms
+	Jitter       string `yaml:"jitter,omitempty"`       // e.g. 20ms
+	Distribution string `yaml:"distribution,omitempty"` // normal | pareto | paretonormal
+	Loss         string `yaml:"loss,omitempty"`         // e.g. 5%
+	Corrupt      string `yaml:"corrupt,omitempty"`      // e.g. 0.1%
+	Duplicate    string `yaml:"duplicate,omitempty"`    // e.g. 1%
+	Reorder      string `yaml:"reorder,omitempty"`      // e.g. 25%, requires delay
+	Rate         string `yaml:"rate,omitempty"`         // e.g. 1mbit
 }
 
 type Schedule struct {
@@ -0,0 +1,81 @@
+package fault
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bandwidth describes a token bucket limit. Rate is in bits per second, Burst and Limit in bytes.
+// A zero Burst or Limit lets the injector pick a default.
+type Bandwidth struct {
+	Rate      uint64
+	Burst     uint64
+	Limit     uint64
+	Direction string // ingress | egress | both, defaults to egress
+}
+
+type unit struct {
+	suffix     string
+	multiplier uint64
+}
+
+// Suffixes are ordered so that longer units match before their shorter tails.
+var rateUnits = []unit{
+	{"tbit", 1_000_000_000_000},
+	{"gbit", 1_000_000_000},
+	{"mbit", 1_000_000},
+	{"kbit", 1_000},
+	{"tbps", 8_000_000_000_000},
+	{"gbps", 8_000_000_000},
+	{"mbps", 8_000_000},
+	{"kbps", 8_000},
+	{"bps", 8},
+	{"bit", 1},
+}
+
+var sizeUnits = []unit{
+	{"gbit", 1024 * 1024 * 1024 / 8},
+	{"mbit", 1024 * 1024 / 8},
+	{"kbit", 1024 / 8},
+	{"gb", 1024 * 1024 * 1024},
+	{"mb", 1024 * 1024},
+	{"kb", 1024},
+	{"g", 1024 * 1024 * 1024},
+	{"m", 1024 * 1024},
+	{"k", 1024},
+	{"b", 1},
+}
+
+// ParseRate parses tc style rates such as "256kbit", "10mbit" or "1mbps" into bits per second.
+// A bare number is interpreted as bits per second, as tc does.
+func ParseRate(raw string) (uint64, error) {
+	value, multiplier := splitUnit(raw, rateUnits)
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidBandwidthRate, raw)
+	}
+
+	return uint64(rate * float64(multiplier)), nil
+}
+
+// ParseSize parses tc style sizes such as "32kb", "1mb" or "1500" into bytes.
+func ParseSize(raw string) (uint64, error) {
+	value, multiplier := splitUnit(raw, sizeUnits)
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, raw)
+	}
+
+	return uint64(size * float64(multiplier)), nil
+}
+
+func splitUnit(raw string, units []unit) (string, uint64) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			return strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.multiplier
+		}
+	}
+	return value, 1
+}
@@ -0,0 +1,55 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	cases := map[string]uint64{
+		"256kbit": 256_000,
+		"10Mbit":  10_000_000,
+		"1mbps":   8_000_000,
+		"1.5gbit": 1_500_000_000,
+		"9600":    9600,
+	}
+
+	for raw, want := range cases {
+		got, err := ParseRate(raw)
+		if err != nil {
+			t.Fatalf("ParseRate(%q) returned error: %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("ParseRate(%q) = %d, want %d", raw, got, want)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	cases := map[string]uint64{
+		"32kb":   32 * 1024,
+		"32k":    32 * 1024,
+		"1mb":    1024 * 1024,
+		"32kbit": 4 * 1024,
+		"1500":   1500,
+		"1500b":  1500,
+	}
+
+	for raw, want := range cases {
+		got, err := ParseSize(raw)
+		if err != nil {
+			t.Fatalf("ParseSize(%q) returned error: %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("ParseSize(%q) = %d, want %d", raw, got, want)
+		}
+	}
+}
+
+func TestParseRate_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "fast", "-1mbit", "0kbit", "kbit"} {
+		if _, err := ParseRate(raw); !errors.Is(err, ErrInvalidBandwidthRate) {
+			t.Fatalf("ParseRate(%q): expected ErrInvalidBandwidthRate, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package fault
+
+import "context"
+
+// OOMInspector reports whether Docker saw an OOM kill in a container.
+type OOMInspector interface {
+	OOMKilled(ctx context.Context, containerID string) (bool, error)
+}
+
+// Injectors are the implementations behind the built-in plugins. A nil injector leaves its
+// fault types registered, but injecting them fails with a "not configured" error.
+type Injectors struct {
+	Injector      FaultInjector
+	Partitioner   NetworkPartitioner
+	Blackholer    PortBlackholer
+	Disconnector  NetworkDisconnector
+	DNS           DNSFaultInjector
+	HTTP          HTTPFaultInjector
+	Resetter      ConnectionResetter
+	CPU           CPUStressor
+	Memory        MemoryStressor
+	IO            IOThrottler
+	Disk          DiskFiller
+	Exhauster     ResourceExhauster
+	Pauser        ContainerPauser
+	Cycler        ContainerCycler
+	Killer        ContainerKiller
+	ProcessKiller ProcessKiller
+	Clock         ClockSkewer
+	Inspector     OOMInspector
+}
+
+// BuiltinPlugins returns the plugins for every fault type chaos-dock ships with. Pause comes
+// first, since the other reverts need the container running.
+func BuiltinPlugins(in Injectors) []Plugin {
+	return []Plugin{
+		&pausePlugin{pauser: in.Pauser},
+		&netemPlugin{injector: in.Injector},
+		&partitionPlugin{partitioner: in.Partitioner},
+		&blackholePlugin{blackholer: in.Blackholer},
+		&disconnectPlugin{disconnector: in.Disconnector},
+		&dnsPlugin{dns: in.DNS},
+		&httpPlugin{http: in.HTTP},
+		&tcpResetPlugin{resetter: in.Resetter},
+		&cpuStressPlugin{cpu: in.CPU},
+		&memoryStressPlugin{memory: in.Memory, inspector: in.Inspector},
+		&ioThrottlePlugin{io: in.IO},
+		&diskFillPlugin{disk: in.Disk},
+		&resourceExhaustionPlugin{exhauster: in.Exhauster},
+		&clockSkewPlugin{clock: in.Clock},
+		&cyclePlugin{cycler: in.Cycler},
+		&killPlugin{killer: in.Killer},
+		&processKillPlugin{killer: in.ProcessKiller},
+	}
+}
+
+// NewBuiltinRegistry returns a registry holding the built-in plugins. Embedding programs may
+// register their own plugins on it for fault types chaos-dock does not ship.
+func NewBuiltinRegistry(in Injectors) (*Registry, error) {
+	return NewRegistry(BuiltinPlugins(in)...)
+}
@@ -0,0 +1,182 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+type pausePlugin struct {
+	pauser ContainerPauser
+}
+
+func (p *pausePlugin) Types() []string { return []string{"pause"} }
+
+func (p *pausePlugin) RunnerReverts() bool { return false }
+
+func (p *pausePlugin) Validate(exp domainconfig.Experiment) error {
+	return validateDuration(exp.Fault, true)
+}
+
+func (p *pausePlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.pauser == nil {
+		return Outcome{}, fmt.Errorf("container pauser is not configured")
+	}
+	duration, err := time.ParseDuration(spec.Duration)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse pause duration %q: %w", spec.Duration, err)
+	}
+	if err := p.pauser.PauseContainer(ctx, containerID, duration); err != nil {
+		return Outcome{}, fmt.Errorf("pause container: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *pausePlugin) Revert(ctx context.Context, containerID string) error {
+	if p.pauser == nil {
+		return nil
+	}
+	return p.pauser.RevertPause(ctx, containerID)
+}
+
+func (p *pausePlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	duration, _ := time.ParseDuration(spec.Duration)
+	return fmt.Sprintf("paused %s for %s", containerID, duration)
+}
+
+// cyclePlugin stops or restarts a container. Both end with the container running again, so
+// there is nothing to revert.
+type cyclePlugin struct {
+	cycler ContainerCycler
+}
+
+func (p *cyclePlugin) Types() []string { return []string{"stop", "restart-loop"} }
+
+func (p *cyclePlugin) RunnerReverts() bool { return false }
+
+func (p *cyclePlugin) Validate(exp domainconfig.Experiment) error {
+	if exp.Fault.Type == "restart-loop" {
+		return validateRestartLoop(exp.Fault)
+	}
+	return validateDuration(exp.Fault, true)
+}
+
+func (p *cyclePlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.cycler == nil {
+		return Outcome{}, fmt.Errorf("container cycler is not configured")
+	}
+
+	if spec.Type == "restart-loop" {
+		loop, err := parseRestartLoop(spec)
+		if err != nil {
+			return Outcome{}, fmt.Errorf("parse restart-loop: %w", err)
+		}
+		if err := p.cycler.RestartContainer(ctx, containerID, loop); err != nil {
+			return Outcome{}, fmt.Errorf("restart container: %w", err)
+		}
+		return Outcome{}, nil
+	}
+
+	downtime, err := time.ParseDuration(spec.Duration)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse stop duration %q: %w", spec.Duration, err)
+	}
+	if err := p.cycler.StopContainer(ctx, containerID, downtime); err != nil {
+		return Outcome{}, fmt.Errorf("stop container: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *cyclePlugin) Revert(context.Context, string) error { return nil }
+
+func (p *cyclePlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	if spec.Type == "restart-loop" {
+		loop, _ := parseRestartLoop(spec)
+		message := fmt.Sprintf("restarted %s %d time(s)", containerID, loop.Count)
+		if loop.Count > 1 {
+			message += fmt.Sprintf(" every %s", loop.Interval)
+		}
+		return message
+	}
+
+	downtime, _ := time.ParseDuration(spec.Duration)
+	return fmt.Sprintf("stopped %s for %s", containerID, downtime)
+}
+
+type killPlugin struct {
+	killer ContainerKiller
+}
+
+func (p *killPlugin) Types() []string { return []string{"kill"} }
+
+func (p *killPlugin) RunnerReverts() bool { return false }
+
+func (p *killPlugin) Validate(exp domainconfig.Experiment) error {
+	if exp.Fault.Signal != "" && !isSupportedSignal(exp.Fault.Signal) {
+		return fmt.Errorf("fault.signal %q is not supported", exp.Fault.Signal)
+	}
+	return nil
+}
+
+func (p *killPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.killer == nil {
+		return Outcome{}, fmt.Errorf("container killer is not configured")
+	}
+	if err := p.killer.KillContainer(ctx, containerID, strings.TrimSpace(spec.Signal)); err != nil {
+		return Outcome{}, fmt.Errorf("kill container: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *killPlugin) Revert(context.Context, string) error { return nil }
+
+func (p *killPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	signal := strings.TrimSpace(spec.Signal)
+	if signal == "" {
+		signal = "SIGKILL"
+	}
+	return fmt.Sprintf("sent %s to %s", signal, containerID)
+}
+
+type processKillPlugin struct {
+	killer ProcessKiller
+}
+
+func (p *processKillPlugin) Types() []string { return []string{"process-kill"} }
+
+func (p *processKillPlugin) RunnerReverts() bool { return false }
+
+func (p *processKillPlugin) Validate(exp domainconfig.Experiment) error {
+	if exp.Fault.Signal != "" && !isSupportedSignal(exp.Fault.Signal) {
+		return fmt.Errorf("fault.signal %q is not supported", exp.Fault.Signal)
+	}
+	return validateProcessMatch(exp.Fault)
+}
+
+func (p *processKillPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.killer == nil {
+		return Outcome{}, fmt.Errorf("process killer is not configured")
+	}
+	count, err := p.killer.KillProcesses(ctx, containerID, parseProcessKill(spec))
+	if err != nil {
+		return Outcome{}, fmt.Errorf("kill processes: %w", err)
+	}
+	return Outcome{ProcessesKilled: count}, nil
+}
+
+func (p *processKillPlugin) Revert(context.Context, string) error { return nil }
+
+func (p *processKillPlugin) Describe(containerID string, spec domainconfig.Fault, outcome Outcome) string {
+	return describeProcessKill(containerID, parseProcessKill(spec), outcome.ProcessesKilled)
+}
+
+func parseProcessKill(f domainconfig.Fault) ProcessKill {
+	return ProcessKill{
+		Name:    strings.TrimSpace(f.Process),
+		Cmdline: strings.TrimSpace(f.Cmdline),
+		Signal:  strings.TrimSpace(f.Signal),
+	}
+}
@@ -0,0 +1,450 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+// netemPlugin applies the qdisc based faults: netem impairments and bandwidth limits.
+type netemPlugin struct {
+	injector FaultInjector
+}
+
+func (p *netemPlugin) Types() []string {
+	return []string{"network", "network-latency", "network-loss", "network-corrupt", "network-duplicate", "network-reorder", "bandwidth"}
+}
+
+func (p *netemPlugin) RunnerReverts() bool { return true }
+
+func (p *netemPlugin) Validate(exp domainconfig.Experiment) error {
+	f := exp.Fault
+	switch f.Type {
+	case "network":
+		if f.Network == nil {
+			return fmt.Errorf("fault.network is required for network")
+		}
+		if err := validateNetworkImpairment(*f.Network); err != nil {
+			return fmt.Errorf("fault.network.%w", err)
+		}
+	case "network-latency":
+		if strings.TrimSpace(f.Delay) == "" {
+			return fmt.Errorf("fault.delay is required for network-latency")
+		}
+		if _, err := time.ParseDuration(f.Delay); err != nil {
+			return fmt.Errorf("fault.delay must be a valid duration: %w", err)
+		}
+		if err := validateJitter(f); err != nil {
+			return err
+		}
+	case "network-loss":
+		if strings.TrimSpace(f.Loss) == "" {
+			return fmt.Errorf("fault.loss is required for network-loss")
+		}
+		loss, err := ParsePercent(f.Loss)
+		if err != nil {
+			return fmt.Errorf("fault.loss must be a valid percentage: %w", err)
+		}
+		if loss == 0 {
+			return fmt.Errorf("fault.loss must be greater than zero")
+		}
+		if f.Correlation != "" {
+			if _, err := ParsePercent(f.Correlation); err != nil {
+				return fmt.Errorf("fault.correlation must be a valid percentage: %w", err)
+			}
+		}
+	case "bandwidth":
+		if strings.TrimSpace(f.Rate) == "" {
+			return fmt.Errorf("fault.rate is required for bandwidth")
+		}
+		if _, err := ParseRate(f.Rate); err != nil {
+			return fmt.Errorf("fault.rate must be a valid rate: %w", err)
+		}
+		if f.Burst != "" {
+			if _, err := ParseSize(f.Burst); err != nil {
+				return fmt.Errorf("fault.burst must be a valid size: %w", err)
+			}
+		}
+		if f.Limit != "" {
+			if _, err := ParseSize(f.Limit); err != nil {
+				return fmt.Errorf("fault.limit must be a valid size: %w", err)
+			}
+		}
+	default:
+		if err := validateImpairment(f); err != nil {
+			return err
+		}
+	}
+
+	if !isSupportedDirection(f.Direction) {
+		return fmt.Errorf("fault.direction %q is not supported", f.Direction)
+	}
+	if err := validateDestination(f); err != nil {
+		return err
+	}
+	return validateDuration(f, false)
+}
+
+func (p *netemPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.injector == nil {
+		return Outcome{}, fmt.Errorf("fault injector is not configured")
+	}
+
+	switch spec.Type {
+	case "network":
+		if spec.Network == nil {
+			return Outcome{}, fmt.Errorf("network impairment block is required")
+		}
+		netem, err := p.netem(spec)
+		if err != nil {
+			return Outcome{}, fmt.Errorf("parse network impairment: %w", err)
+		}
+		if err := p.injector.InjectNetem(ctx, containerID, netem); err != nil {
+			return Outcome{}, fmt.Errorf("inject network impairment: %w", err)
+		}
+	case "network-latency":
+		netem, err := p.netem(spec)
+		if err != nil {
+			return Outcome{}, fmt.Errorf("parse network-latency: %w", err)
+		}
+		if isPlainEgress(netem) {
+			err = p.injector.InjectNetworkLatency(ctx, containerID, *netem.Latency)
+		} else {
+			err = p.injector.InjectNetem(ctx, containerID, netem)
+		}
+		if err != nil {
+			return Outcome{}, fmt.Errorf("inject network latency: %w", err)
+		}
+	case "network-loss":
+		netem, err := p.netem(spec)
+		if err != nil {
+			return Outcome{}, fmt.Errorf("parse network-loss: %w", err)
+		}
+		if isPlainEgress(netem) {
+			err = p.injector.InjectPacketLoss(ctx, containerID, *netem.Loss)
+		} else {
+			err = p.injector.InjectNetem(ctx, containerID, netem)
+		}
+		if err != nil {
+			return Outcome{}, fmt.Errorf("inject packet loss: %w", err)
+		}
+	case "bandwidth":
+		bandwidth, err := parseBandwidth(spec)
+		if err != nil {
+			return Outcome{}, fmt.Errorf("parse bandwidth: %w", err)
+		}
+		if err := p.injector.InjectBandwidthLimit(ctx, containerID, bandwidth); err != nil {
+			return Outcome{}, fmt.Errorf("inject bandwidth limit: %w", err)
+		}
+	default:
+		netem, err := p.netem(spec)
+		if err != nil {
+			return Outcome{}, fmt.Errorf("parse %s: %w", spec.Type, err)
+		}
+		if err := p.injector.InjectNetem(ctx, containerID, netem); err != nil {
+			return Outcome{}, fmt.Errorf("inject %s: %w", spec.Type, err)
+		}
+	}
+	return Outcome{}, nil
+}
+
+func (p *netemPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.injector == nil {
+		return nil
+	}
+	return p.injector.RevertNetworkLatency(ctx, containerID)
+}
+
+func (p *netemPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	if spec.Type == "bandwidth" {
+		bandwidth, _ := parseBandwidth(spec)
+		return fmt.Sprintf("limited %s bandwidth to %s%s", containerID, strings.TrimSpace(spec.Rate), describeDirection(bandwidth.Direction))
+	}
+
+	netem, _ := p.netem(spec)
+	switch {
+	case spec.Type == "network-latency" && netem.Latency != nil:
+		return fmt.Sprintf("applied %s network delay to %s%s", describeLatency(*netem.Latency), containerID, describeScope(netem))
+	case spec.Type == "network-loss" && netem.Loss != nil:
+		return fmt.Sprintf("applied %s packet loss to %s%s", describeProbability(*netem.Loss), containerID, describeScope(netem))
+	default:
+		return fmt.Sprintf("applied %s to %s%s", describeNetem(netem), containerID, describeScope(netem))
+	}
+}
+
+// netem builds the qdisc of every type but bandwidth, scoped by fault.direction and fault.to.
+func (p *netemPlugin) netem(spec domainconfig.Fault) (Netem, error) {
+	var netem Netem
+	switch spec.Type {
+	case "network":
+		if spec.Network == nil {
+			return Netem{}, fmt.Errorf("network impairment block is required")
+		}
+		var err error
+		if netem, err = parseNetworkImpairment(*spec.Network); err != nil {
+			return Netem{}, err
+		}
+	case "network-latency":
+		latency, err := parseLatency(spec)
+		if err != nil {
+			return Netem{}, err
+		}
+		netem.Latency = &latency
+	case "network-loss":
+		loss, err := parseProbability(spec.Loss, spec.Correlation)
+		if err != nil {
+			return Netem{}, err
+		}
+		netem.Loss = &loss
+	default:
+		return parseImpairment(spec)
+	}
+	netem.Direction = parseDirection(spec)
+	netem.To = parseDestination(spec)
+	return netem, nil
+}
+
+type partitionPlugin struct {
+	partitioner NetworkPartitioner
+}
+
+func (p *partitionPlugin) Types() []string { return []string{"network-partition"} }
+
+func (p *partitionPlugin) RunnerReverts() bool { return true }
+
+func (p *partitionPlugin) Validate(exp domainconfig.Experiment) error {
+	if len(exp.Fault.Peers) == 0 {
+		return fmt.Errorf("fault.peers is required for network-partition")
+	}
+	for j, peer := range exp.Fault.Peers {
+		if strings.TrimSpace(peer) == "" {
+			return fmt.Errorf("fault.peers[%d] must not be empty", j)
+		}
+		if strings.TrimSpace(peer) == strings.TrimSpace(exp.TargetContainer) {
+			return fmt.Errorf("fault.peers[%d] must differ from targetContainer", j)
+		}
+	}
+	return validateDuration(exp.Fault, false)
+}
+
+func (p *partitionPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.partitioner == nil {
+		return Outcome{}, fmt.Errorf("network partitioner is not configured")
+	}
+	if err := p.partitioner.PartitionNetwork(ctx, containerID, spec.Peers); err != nil {
+		return Outcome{}, fmt.Errorf("partition network: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *partitionPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.partitioner == nil {
+		return nil
+	}
+	return p.partitioner.RevertNetworkPartition(ctx, containerID)
+}
+
+func (p *partitionPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	return fmt.Sprintf("partitioned %s from %s", containerID, strings.Join(spec.Peers, ", "))
+}
+
+type blackholePlugin struct {
+	blackholer PortBlackholer
+}
+
+func (p *blackholePlugin) Types() []string { return []string{"port-blackhole"} }
+
+func (p *blackholePlugin) RunnerReverts() bool { return true }
+
+func (p *blackholePlugin) Validate(exp domainconfig.Experiment) error {
+	if err := validatePortBlackhole(exp.Fault); err != nil {
+		return err
+	}
+	return validateDuration(exp.Fault, false)
+}
+
+func (p *blackholePlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.blackholer == nil {
+		return Outcome{}, fmt.Errorf("port blackholer is not configured")
+	}
+	if err := p.blackholer.BlackholePorts(ctx, containerID, parsePortBlackhole(spec)); err != nil {
+		return Outcome{}, fmt.Errorf("blackhole ports: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *blackholePlugin) Revert(ctx context.Context, containerID string) error {
+	if p.blackholer == nil {
+		return nil
+	}
+	return p.blackholer.RevertPortBlackhole(ctx, containerID)
+}
+
+func (p *blackholePlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	return describePortBlackhole(containerID, parsePortBlackhole(spec))
+}
+
+type disconnectPlugin struct {
+	disconnector NetworkDisconnector
+}
+
+func (p *disconnectPlugin) Types() []string { return []string{"network-disconnect"} }
+
+func (p *disconnectPlugin) RunnerReverts() bool { return false }
+
+func (p *disconnectPlugin) Validate(exp domainconfig.Experiment) error {
+	if strings.TrimSpace(exp.Fault.DockerNetwork) == "" {
+		return fmt.Errorf("fault.dockerNetwork is required for network-disconnect")
+	}
+	return validateDuration(exp.Fault, true)
+}
+
+func (p *disconnectPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.disconnector == nil {
+		return Outcome{}, fmt.Errorf("network disconnector is not configured")
+	}
+
+	disconnect := NetworkDisconnect{Network: strings.TrimSpace(spec.DockerNetwork)}
+	duration, err := time.ParseDuration(spec.Duration)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse network-disconnect duration %q: %w", spec.Duration, err)
+	}
+	disconnect.Duration = duration
+
+	if err := p.disconnector.DisconnectNetwork(ctx, containerID, disconnect); err != nil {
+		return Outcome{}, fmt.Errorf("disconnect network: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *disconnectPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.disconnector == nil {
+		return nil
+	}
+	return p.disconnector.RevertNetworkDisconnect(ctx, containerID)
+}
+
+func (p *disconnectPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	duration, _ := time.ParseDuration(spec.Duration)
+	return fmt.Sprintf("disconnected %s from network %s for %s", containerID, strings.TrimSpace(spec.DockerNetwork), duration)
+}
+
+type dnsPlugin struct {
+	dns DNSFaultInjector
+}
+
+func (p *dnsPlugin) Types() []string { return []string{"dns"} }
+
+func (p *dnsPlugin) RunnerReverts() bool { return false }
+
+func (p *dnsPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateDNS(exp.Fault)
+}
+
+func (p *dnsPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.dns == nil {
+		return Outcome{}, fmt.Errorf("dns fault injector is not configured")
+	}
+	dnsFault, err := parseDNSFault(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse dns fault: %w", err)
+	}
+	if err := p.dns.InjectDNSFault(ctx, containerID, dnsFault); err != nil {
+		return Outcome{}, fmt.Errorf("inject dns fault: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *dnsPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.dns == nil {
+		return nil
+	}
+	return p.dns.RevertDNSFault(ctx, containerID)
+}
+
+func (p *dnsPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	dnsFault, _ := parseDNSFault(spec)
+	return describeDNSFault(containerID, dnsFault)
+}
+
+type httpPlugin struct {
+	http HTTPFaultInjector
+}
+
+func (p *httpPlugin) Types() []string { return []string{"http"} }
+
+func (p *httpPlugin) RunnerReverts() bool { return false }
+
+func (p *httpPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateHTTP(exp.Fault)
+}
+
+func (p *httpPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.http == nil {
+		return Outcome{}, fmt.Errorf("http fault injector is not configured")
+	}
+	httpFault, err := parseHTTPFault(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse http fault: %w", err)
+	}
+	if err := p.http.InjectHTTPFault(ctx, containerID, httpFault); err != nil {
+		return Outcome{}, fmt.Errorf("inject http fault: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *httpPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.http == nil {
+		return nil
+	}
+	return p.http.RevertHTTPFault(ctx, containerID)
+}
+
+func (p *httpPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	httpFault, _ := parseHTTPFault(spec)
+	return describeHTTPFault(containerID, httpFault)
+}
+
+type tcpResetPlugin struct {
+	resetter ConnectionResetter
+}
+
+func (p *tcpResetPlugin) Types() []string { return []string{"tcp-reset"} }
+
+func (p *tcpResetPlugin) RunnerReverts() bool { return false }
+
+func (p *tcpResetPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateTCPReset(exp.Fault)
+}
+
+func (p *tcpResetPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.resetter == nil {
+		return Outcome{}, fmt.Errorf("connection resetter is not configured")
+	}
+	reset, err := parseTCPReset(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse tcp-reset: %w", err)
+	}
+
+	count, err := p.resetter.ResetConnections(ctx, containerID, reset)
+	outcome := Outcome{ConnectionsReset: count}
+	if err != nil {
+		return outcome, fmt.Errorf("reset connections: %w", err)
+	}
+	return outcome, nil
+}
+
+func (p *tcpResetPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.resetter == nil {
+		return nil
+	}
+	return p.resetter.RevertTCPReset(ctx, containerID)
+}
+
+func (p *tcpResetPlugin) Describe(containerID string, spec domainconfig.Fault, outcome Outcome) string {
+	reset, _ := parseTCPReset(spec)
+	return describeTCPReset(containerID, reset, outcome.ConnectionsReset)
+}
@@ -0,0 +1,246 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+type cpuStressPlugin struct {
+	cpu CPUStressor
+}
+
+func (p *cpuStressPlugin) Types() []string { return []string{"cpu-stress"} }
+
+func (p *cpuStressPlugin) RunnerReverts() bool { return false }
+
+func (p *cpuStressPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateCPUStress(exp.Fault)
+}
+
+func (p *cpuStressPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.cpu == nil {
+		return Outcome{}, fmt.Errorf("cpu stressor is not configured")
+	}
+	stress, err := parseCPUStress(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse cpu-stress: %w", err)
+	}
+	if err := p.cpu.StressCPU(ctx, containerID, stress); err != nil {
+		return Outcome{}, fmt.Errorf("stress cpu: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *cpuStressPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.cpu == nil {
+		return nil
+	}
+	return p.cpu.RevertCPUStress(ctx, containerID)
+}
+
+func (p *cpuStressPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	stress, _ := parseCPUStress(spec)
+	return fmt.Sprintf("ran %d cpu worker(s) at %g%% load in %s for %s", stress.Workers, stress.Load, containerID, stress.Duration)
+}
+
+type memoryStressPlugin struct {
+	memory    MemoryStressor
+	inspector OOMInspector
+}
+
+func (p *memoryStressPlugin) Types() []string { return []string{"memory-stress"} }
+
+func (p *memoryStressPlugin) RunnerReverts() bool { return false }
+
+func (p *memoryStressPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateMemoryStress(exp.Fault)
+}
+
+func (p *memoryStressPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.memory == nil {
+		return Outcome{}, fmt.Errorf("memory stressor is not configured")
+	}
+	stress, err := parseMemoryStress(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse memory-stress: %w", err)
+	}
+
+	var outcome Outcome
+	stressErr := p.memory.StressMemory(ctx, containerID, stress)
+	if p.inspector != nil {
+		// The OOM killer may have hit the container's own processes, so check even on error.
+		if oomKilled, err := p.inspector.OOMKilled(ctx, containerID); err == nil {
+			outcome.OOMKilled = oomKilled
+		}
+	}
+	if stressErr != nil {
+		return outcome, fmt.Errorf("stress memory: %w", stressErr)
+	}
+	return outcome, nil
+}
+
+func (p *memoryStressPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.memory == nil {
+		return nil
+	}
+	return p.memory.RevertMemoryStress(ctx, containerID)
+}
+
+func (p *memoryStressPlugin) Describe(containerID string, spec domainconfig.Fault, outcome Outcome) string {
+	stress, _ := parseMemoryStress(spec)
+	return describeMemoryStress(containerID, stress, outcome.OOMKilled)
+}
+
+type ioThrottlePlugin struct {
+	io IOThrottler
+}
+
+func (p *ioThrottlePlugin) Types() []string { return []string{"io-throttle"} }
+
+func (p *ioThrottlePlugin) RunnerReverts() bool { return false }
+
+func (p *ioThrottlePlugin) Validate(exp domainconfig.Experiment) error {
+	return validateIOThrottle(exp.Fault)
+}
+
+func (p *ioThrottlePlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.io == nil {
+		return Outcome{}, fmt.Errorf("io throttler is not configured")
+	}
+	throttle, err := parseIOThrottle(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse io-throttle: %w", err)
+	}
+	if err := p.io.ThrottleIO(ctx, containerID, throttle); err != nil {
+		return Outcome{}, fmt.Errorf("throttle io: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *ioThrottlePlugin) Revert(ctx context.Context, containerID string) error {
+	if p.io == nil {
+		return nil
+	}
+	return p.io.RevertIOThrottle(ctx, containerID)
+}
+
+func (p *ioThrottlePlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	throttle, _ := parseIOThrottle(spec)
+	return describeIOThrottle(containerID, throttle)
+}
+
+type diskFillPlugin struct {
+	disk DiskFiller
+}
+
+func (p *diskFillPlugin) Types() []string { return []string{"disk-fill"} }
+
+func (p *diskFillPlugin) RunnerReverts() bool { return false }
+
+func (p *diskFillPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateDiskFill(exp.Fault)
+}
+
+func (p *diskFillPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.disk == nil {
+		return Outcome{}, fmt.Errorf("disk filler is not configured")
+	}
+	fill, err := parseDiskFill(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse disk-fill: %w", err)
+	}
+	if err := p.disk.FillDisk(ctx, containerID, fill); err != nil {
+		return Outcome{}, fmt.Errorf("fill disk: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *diskFillPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.disk == nil {
+		return nil
+	}
+	return p.disk.RevertDiskFill(ctx, containerID)
+}
+
+func (p *diskFillPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	fill, _ := parseDiskFill(spec)
+	return describeDiskFill(containerID, fill)
+}
+
+type resourceExhaustionPlugin struct {
+	exhauster ResourceExhauster
+}
+
+func (p *resourceExhaustionPlugin) Types() []string { return []string{"resource-exhaustion"} }
+
+func (p *resourceExhaustionPlugin) RunnerReverts() bool { return false }
+
+func (p *resourceExhaustionPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateResourceExhaustion(exp.Fault)
+}
+
+func (p *resourceExhaustionPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.exhauster == nil {
+		return Outcome{}, fmt.Errorf("resource exhauster is not configured")
+	}
+	exhaustion, err := parseResourceExhaustion(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse resource-exhaustion: %w", err)
+	}
+	if err := p.exhauster.ExhaustResource(ctx, containerID, exhaustion); err != nil {
+		return Outcome{}, fmt.Errorf("exhaust %s: %w", exhaustion.Resource, err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *resourceExhaustionPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.exhauster == nil {
+		return nil
+	}
+	return p.exhauster.RevertResourceExhaustion(ctx, containerID)
+}
+
+func (p *resourceExhaustionPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	exhaustion, _ := parseResourceExhaustion(spec)
+	return describeResourceExhaustion(containerID, exhaustion)
+}
+
+type clockSkewPlugin struct {
+	clock ClockSkewer
+}
+
+func (p *clockSkewPlugin) Types() []string { return []string{"clock-skew"} }
+
+func (p *clockSkewPlugin) RunnerReverts() bool { return false }
+
+func (p *clockSkewPlugin) Validate(exp domainconfig.Experiment) error {
+	return validateClockSkew(exp.Fault)
+}
+
+func (p *clockSkewPlugin) Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error) {
+	if p.clock == nil {
+		return Outcome{}, fmt.Errorf("clock skewer is not configured")
+	}
+	skew, err := parseClockSkew(spec)
+	if err != nil {
+		return Outcome{}, fmt.Errorf("parse clock-skew: %w", err)
+	}
+	if err := p.clock.SkewClock(ctx, containerID, skew); err != nil {
+		return Outcome{}, fmt.Errorf("skew clock: %w", err)
+	}
+	return Outcome{}, nil
+}
+
+func (p *clockSkewPlugin) Revert(ctx context.Context, containerID string) error {
+	if p.clock == nil {
+		return nil
+	}
+	return p.clock.RevertClockSkew(ctx, containerID)
+}
+
+func (p *clockSkewPlugin) Describe(containerID string, spec domainconfig.Fault, _ Outcome) string {
+	skew, _ := parseClockSkew(spec)
+	return fmt.Sprintf("skewed the clock of %s by %s for %s", containerID, describeOffset(skew.Offset), skew.Duration)
+}
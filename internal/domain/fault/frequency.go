@@ -0,0 +1,31 @@
+package fault
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseFrequency parses values such as "5/s", "30/m" or "2/h" into events per second.
+func ParseFrequency(raw string) (float64, error) {
+	count, unit, ok := strings.Cut(strings.TrimSpace(raw), "/")
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidFrequency, raw)
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+	if err != nil || !(n > 0) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidFrequency, raw)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "s":
+		return n, nil
+	case "m":
+		return n / 60, nil
+	case "h":
+		return n / 3600, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidFrequency, raw)
+	}
+}
@@ -0,0 +1,33 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFrequency(t *testing.T) {
+	cases := map[string]float64{
+		"5/s":    5,
+		"30/m":   0.5,
+		" 36/h ": 0.01,
+		"0.5/s":  0.5,
+	}
+
+	for raw, want := range cases {
+		got, err := ParseFrequency(raw)
+		if err != nil {
+			t.Fatalf("ParseFrequency(%q) returned error: %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("ParseFrequency(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestParseFrequency_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "5", "0/s", "-1/s", "5/d", "x/s", "NaN/s"} {
+		if _, err := ParseFrequency(raw); !errors.Is(err, ErrInvalidFrequency) {
+			t.Fatalf("ParseFrequency(%q): expected ErrInvalidFrequency, got %v", raw, err)
+		}
+	}
+}
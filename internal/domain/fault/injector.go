@@ -9,24 +9,347 @@ import (
 var (
 	ErrInvalidContainerID          = errors.New("container id is required")
 	ErrInvalidLatencyDuration      = errors.New("latency duration must be greater than zero")
+	ErrInvalidJitterDuration       = errors.New("latency jitter must be zero or positive")
+	ErrInvalidDistribution         = errors.New("invalid latency distribution")
+	ErrInvalidPercentage           = errors.New("percentage must be between 0 and 100")
+	ErrReorderRequiresDelay        = errors.New("packet reordering requires a delay")
+	ErrEmptyNetem                  = errors.New("at least one netem impairment is required")
+	ErrInvalidBandwidthRate        = errors.New("bandwidth rate must be greater than zero")
+	ErrInvalidSize                 = errors.New("size must be greater than zero")
+	ErrNoPartitionPeers            = errors.New("network partition requires at least one peer container")
+	ErrPeerAddressUnavailable      = errors.New("peer container has no ip address")
+	ErrInvalidPort                 = errors.New("port must be between 1 and 65535")
+	ErrInvalidProtocol             = errors.New("protocol must be tcp or udp")
+	ErrInvalidDirection            = errors.New("direction must be ingress, egress or both")
+	ErrInvalidDNSMode              = errors.New("dns fault mode must be nxdomain, servfail or delay")
+	ErrInvalidFaultDuration        = errors.New("fault duration must be greater than zero")
+	ErrDNSResolverUnavailable      = errors.New("container has no ipv4 nameserver configured")
 	ErrInvalidKillSignal           = errors.New("invalid kill signal")
+	ErrInvalidWorkerCount          = errors.New("worker count must be greater than zero")
+	ErrCgroupUnavailable           = errors.New("container cgroup is unavailable")
+	ErrStressHelperFailed          = errors.New("stress helper process failed")
+	ErrMemoryLimitUnavailable      = errors.New("container has no memory limit")
+	ErrInvalidResourceExhaustion   = errors.New("resource exhaustion needs pids or files and a count or percentage")
+	ErrResourceLimitUnavailable    = errors.New("container has no limit for the resource")
+	ErrInvalidIOThrottle           = errors.New("io throttle needs a device and at least one limit")
+	ErrBlockDeviceUnavailable      = errors.New("block device is unavailable")
+	ErrInvalidDiskFill             = errors.New("disk fill needs a path or volume and a size")
+	ErrVolumeNotMounted            = errors.New("volume is not mounted in container")
 	ErrNamespaceToolMissing        = errors.New("namespace tooling is unavailable on host")
 	ErrNetworkNamespaceUnavailable = errors.New("container network namespace is unavailable")
 	ErrIPRoute2Missing             = errors.New("iproute2/tc is not available in target namespace")
+	ErrIFBUnavailable              = errors.New("ifb device cannot be created, is the ifb kernel module loaded")
+	ErrEmptyDestination            = errors.New("destination filter needs at least one host or port")
+	ErrInvalidDestination          = errors.New("destination host must be an ip, cidr or container name")
+	ErrDestinationEgressOnly       = errors.New("destination filters only apply to egress traffic")
 	ErrInsufficientPrivileges      = errors.New("insufficient privileges to alter qdisc")
 	ErrCommandTimeout              = errors.New("fault injection command timed out")
 	ErrTCCommandFailed             = errors.New("tc command execution failed")
+	ErrIPTablesMissing             = errors.New("iptables is not available on host")
+	ErrIPTablesCommandFailed       = errors.New("iptables command execution failed")
 	ErrContainerKillFailed         = errors.New("container kill failed")
+	ErrInvalidFrequency            = errors.New("invalid frequency")
+	ErrSSMissing                   = errors.New("ss is not available on host")
+	ErrSSCommandFailed             = errors.New("ss command execution failed")
+	ErrSocketDestroyFailed         = errors.New("kernel refused to destroy sockets, is CONFIG_INET_DIAG_DESTROY enabled")
+	ErrInvalidHTTPFault            = errors.New("invalid http fault")
+	ErrInvalidClockSkew            = errors.New("invalid clock skew")
+	ErrClockSkewUnsupported        = errors.New("clock skew needs the container to preload libfaketime")
+	ErrInvalidProcessMatch         = errors.New("invalid process match")
+	ErrNoMatchingProcess           = errors.New("no matching process in container")
+	ErrProcessKillFailed           = errors.New("process kill failed")
+	ErrContainerPauseFailed        = errors.New("container pause failed")
+	ErrContainerStopFailed         = errors.New("container stop failed")
+	ErrContainerRestartFailed      = errors.New("container restart failed")
+	ErrInvalidRestartLoop          = errors.New("invalid restart loop")
+	ErrNetworkNotAttached          = errors.New("container is not attached to network")
+	ErrNetworkDisconnectFailed     = errors.New("network disconnect failed")
+	ErrNetworkReconnectFailed      = errors.New("network reconnect failed")
 	ErrUnsupportedPlatform         = errors.New("this injector supports linux hosts only")
+	ErrUnknownFaultType            = errors.New("no plugin registered for fault type")
+	ErrDuplicateFaultType          = errors.New("fault type is already registered")
 )
 
+// Latency describes a netem delay. Correlation and Distribution only apply when Jitter is set.
+type Latency struct {
+	Delay        time.Duration
+	Jitter       time.Duration
+	Correlation  float64
+	Distribution string // normal | pareto | paretonormal
+}
+
+// Probability is a netem percentage with an optional correlation to the previous packet.
+type Probability struct {
+	Percent     float64
+	Correlation float64
+}
+
+// Netem combines impairments rendered into a single netem qdisc. Nil or zero fields are not applied.
+type Netem struct {
+	Latency   *Latency
+	Loss      *Probability
+	Corrupt   *Probability
+	Duplicate *Probability
+	Reorder   *Probability
+	Rate      uint64 // bits per second
+	Direction string // ingress | egress | both, defaults to egress
+	To        *Destination
+}
+
+// Destination scopes a netem impairment to egress traffic towards Hosts and/or Ports; other
+// traffic is left untouched. Hosts are IPs, CIDRs or container names.
+type Destination struct {
+	Hosts []string
+	Ports []int
+}
+
 // FaultInjector defines fault operations used by the application layer.
+// InjectNetworkLatency and InjectPacketLoss shape egress traffic only, InjectNetem and
+// InjectBandwidthLimit honour their Direction. RevertNetworkLatency undoes both directions.
 type FaultInjector interface {
-	InjectNetworkLatency(ctx context.Context, containerID string, delay time.Duration) error
+	InjectNetworkLatency(ctx context.Context, containerID string, latency Latency) error
+	InjectPacketLoss(ctx context.Context, containerID string, loss Probability) error
+	InjectBandwidthLimit(ctx context.Context, containerID string, bandwidth Bandwidth) error
+	InjectNetem(ctx context.Context, containerID string, netem Netem) error
 	RevertNetworkLatency(ctx context.Context, containerID string) error
 }
 
+// NetworkPartitioner drops traffic between a container and a set of peer containers.
+type NetworkPartitioner interface {
+	PartitionNetwork(ctx context.Context, containerID string, peers []string) error
+	RevertNetworkPartition(ctx context.Context, containerID string) error
+}
+
+// PortBlackhole describes traffic on specific ports that is dropped, or rejected with a
+// TCP reset (ICMP port unreachable for UDP) to simulate a refused connection.
+type PortBlackhole struct {
+	Ports     []int
+	Protocols []string // tcp | udp
+	Direction string   // ingress | egress | both
+	Reject    bool
+}
+
+// PortBlackholer cuts traffic on specific ports inside a container.
+type PortBlackholer interface {
+	BlackholePorts(ctx context.Context, containerID string, blackhole PortBlackhole) error
+	RevertPortBlackhole(ctx context.Context, containerID string) error
+}
+
+// DNSFault makes name resolution for Domains (and their subdomains) fail with NXDOMAIN or
+// SERVFAIL, or slows it down by Delay. An empty Domains list affects every name.
+type DNSFault struct {
+	Mode     string // nxdomain | servfail | delay
+	Domains  []string
+	Delay    time.Duration
+	Duration time.Duration
+}
+
+// DNSFaultInjector intercepts DNS lookups made by a container. InjectDNSFault holds the fault
+// for its Duration and reverts it before returning.
+type DNSFaultInjector interface {
+	InjectDNSFault(ctx context.Context, containerID string, fault DNSFault) error
+	RevertDNSFault(ctx context.Context, containerID string) error
+}
+
 // ContainerKiller defines a fault that terminates container processes.
 type ContainerKiller interface {
 	KillContainer(ctx context.Context, containerID string, signal string) error
 }
+
+// ProcessKill signals processes inside a container whose name equals Name and whose command
+// line matches the Cmdline regular expression. Empty criteria match every process.
+type ProcessKill struct {
+	Name    string
+	Cmdline string
+	Signal  string
+}
+
+// ProcessKiller signals individual processes inside a container and reports how many it
+// signalled. The container's init process is never signalled; ContainerKiller covers it.
+type ProcessKiller interface {
+	KillProcesses(ctx context.Context, containerID string, kill ProcessKill) (int, error)
+}
+
+// TCPReset destroys established TCP connections of a container whose remote end is in
+// Match.Hosts and that use one of Match.Ports on either end; empty fields match everything.
+// Rate limits resets per second; zero resets every matching connection as soon as it is seen.
+type TCPReset struct {
+	Match    Destination
+	Rate     float64
+	Duration time.Duration
+}
+
+// ConnectionResetter resets TCP connections. ResetConnections keeps resetting for the fault's
+// Duration and reports how many connections it destroyed.
+type ConnectionResetter interface {
+	ResetConnections(ctx context.Context, containerID string, reset TCPReset) (int, error)
+	RevertTCPReset(ctx context.Context, containerID string) error
+}
+
+// HTTPRoute selects requests by method and path. Empty fields match every request; a Path
+// ending in "*" matches by prefix.
+type HTTPRoute struct {
+	Method string
+	Path   string
+}
+
+// HTTPFault places a proxy in front of the container's Ports. Percent of the requests that
+// match one of Routes (all requests when there are none) are delayed by Delay and, when
+// AbortStatus is set, answered with that status instead of being forwarded.
+type HTTPFault struct {
+	Ports       []int
+	Routes      []HTTPRoute
+	Percent     float64
+	AbortStatus int
+	Delay       Latency
+	Duration    time.Duration
+}
+
+// HTTPFaultInjector applies HTTP-level faults. InjectHTTPFault holds the fault for its
+// Duration and removes the proxy before returning.
+type HTTPFaultInjector interface {
+	InjectHTTPFault(ctx context.Context, containerID string, fault HTTPFault) error
+	RevertHTTPFault(ctx context.Context, containerID string) error
+}
+
+// ClockSkew shifts a container's view of time by Offset for Duration.
+type ClockSkew struct {
+	Offset   time.Duration
+	Duration time.Duration
+}
+
+// ClockSkewer offsets the clocks seen by a container. SkewClock holds the skew for its
+// Duration and restores the clocks before returning.
+type ClockSkewer interface {
+	SkewClock(ctx context.Context, containerID string, skew ClockSkew) error
+	RevertClockSkew(ctx context.Context, containerID string) error
+}
+
+// ContainerPauser freezes every process of a container. PauseContainer holds the pause for
+// the given duration and unpauses the container before returning.
+type ContainerPauser interface {
+	PauseContainer(ctx context.Context, containerID string, duration time.Duration) error
+	RevertPause(ctx context.Context, containerID string) error
+}
+
+// NetworkDisconnect detaches a container from a Docker network for Duration.
+type NetworkDisconnect struct {
+	Network  string
+	Duration time.Duration
+}
+
+// NetworkEndpoint is a container's attachment to a Docker network, with the settings needed
+// to attach it again. StaticIP reports whether the addresses were configured explicitly
+// rather than assigned by IPAM.
+type NetworkEndpoint struct {
+	Network     string
+	Aliases     []string
+	Links       []string
+	DriverOpts  map[string]string
+	IPv4Address string
+	IPv6Address string
+	StaticIP    bool
+}
+
+// NetworkDisconnector detaches containers from Docker networks. DisconnectNetwork holds the
+// fault for its Duration and reconnects the container before returning.
+type NetworkDisconnector interface {
+	DisconnectNetwork(ctx context.Context, containerID string, fault NetworkDisconnect) error
+	RevertNetworkDisconnect(ctx context.Context, containerID string) error
+}
+
+// RestartLoop restarts a container Count times, waiting Interval between restarts.
+type RestartLoop struct {
+	Count    int
+	Interval time.Duration
+}
+
+// ContainerCycler stops and restarts containers. StopContainer keeps the container stopped
+// for downtime and starts it again before returning.
+type ContainerCycler interface {
+	StopContainer(ctx context.Context, containerID string, downtime time.Duration) error
+	RestartContainer(ctx context.Context, containerID string, loop RestartLoop) error
+}
+
+// CPUStress keeps Workers busy for Load percent of the time, charged to the container's cgroup.
+type CPUStress struct {
+	Workers  int
+	Load     float64 // percent of one CPU per worker, 1-100
+	Duration time.Duration
+}
+
+// MemoryStress allocates Bytes, or LimitPercent of the container memory limit, inside the
+// container's cgroup. With OOM set it keeps allocating past the limit to trigger the OOM killer.
+type MemoryStress struct {
+	Bytes        uint64
+	LimitPercent float64
+	OOM          bool
+	Duration     time.Duration
+}
+
+// MemoryStressor puts a container under memory pressure. StressMemory holds the allocation
+// for its Duration and releases it before returning.
+type MemoryStressor interface {
+	StressMemory(ctx context.Context, containerID string, stress MemoryStress) error
+	RevertMemoryStress(ctx context.Context, containerID string) error
+}
+
+// ResourceExhaustion drives a container towards one of its limits. Resource "pids" fills the
+// container's pids cgroup with idle processes; "files" opens idle sockets in its network
+// namespace, up to the open-file limit of the container's init process. Count is how many to
+// add, or Percent the share of the limit to reach.
+type ResourceExhaustion struct {
+	Resource string // pids | files
+	Count    int
+	Percent  float64
+	Duration time.Duration
+}
+
+// ResourceExhauster holds processes or descriptors in a container. ExhaustResource holds them
+// for the fault's Duration and releases them before returning.
+type ResourceExhauster interface {
+	ExhaustResource(ctx context.Context, containerID string, exhaustion ResourceExhaustion) error
+	RevertResourceExhaustion(ctx context.Context, containerID string) error
+}
+
+// IOThrottle caps the bandwidth (bytes per second) and operations per second a container may
+// use on a block device. Zero values are unlimited.
+type IOThrottle struct {
+	Device    string // e.g. /dev/sda
+	ReadBPS   uint64
+	WriteBPS  uint64
+	ReadIOPS  uint64
+	WriteIOPS uint64
+	Duration  time.Duration
+}
+
+// IOThrottler lowers the disk I/O limits of a container. ThrottleIO holds the limits for
+// their Duration and restores the original limits before returning.
+type IOThrottler interface {
+	ThrottleIO(ctx context.Context, containerID string, throttle IOThrottle) error
+	RevertIOThrottle(ctx context.Context, containerID string) error
+}
+
+// DiskFill writes a ballast file of Bytes into Path, or into the mount point of Volume, inside
+// a container. With Percent set the ballast fills the filesystem up to that share of its size.
+type DiskFill struct {
+	Path     string
+	Volume   string
+	Bytes    uint64
+	Percent  float64
+	Duration time.Duration
+}
+
+// DiskFiller fills container filesystems. FillDisk holds the ballast for its Duration and
+// removes it before returning.
+type DiskFiller interface {
+	FillDisk(ctx context.Context, containerID string, fill DiskFill) error
+	RevertDiskFill(ctx context.Context, containerID string) error
+}
+
+// CPUStressor starves a container of CPU. StressCPU holds the load for its Duration and stops
+// it before returning; RevertCPUStress stops any load still running in the container.
+type CPUStressor interface {
+	StressCPU(ctx context.Context, containerID string, stress CPUStress) error
+	RevertCPUStress(ctx context.Context, containerID string) error
+}
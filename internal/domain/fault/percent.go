@@ -0,0 +1,22 @@
+package fault
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePercent parses values such as "10", "10%" or "0.5%" into a percentage in [0, 100].
+func ParsePercent(raw string) (float64, error) {
+	value := strings.TrimSuffix(strings.TrimSpace(raw), "%")
+	if value == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPercentage, raw)
+	}
+
+	percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || !(percent >= 0 && percent <= 100) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPercentage, raw)
+	}
+
+	return percent, nil
+}
@@ -0,0 +1,34 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePercent(t *testing.T) {
+	cases := map[string]float64{
+		"10":    10,
+		"10%":   10,
+		" 0.5%": 0.5,
+		"100%":  100,
+		"0":     0,
+	}
+
+	for raw, want := range cases {
+		got, err := ParsePercent(raw)
+		if err != nil {
+			t.Fatalf("ParsePercent(%q) returned error: %v", raw, err)
+		}
+		if got != want {
+			t.Fatalf("ParsePercent(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestParsePercent_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "%", "abc", "-1%", "101%", "NaN"} {
+		if _, err := ParsePercent(raw); !errors.Is(err, ErrInvalidPercentage) {
+			t.Fatalf("ParsePercent(%q): expected ErrInvalidPercentage, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+// Plugin implements one or more fault types. The config loader calls Validate, the runner
+// calls Inject and Describe, and the panic button calls Revert on every registered plugin.
+type Plugin interface {
+	// Types returns the fault.type values the plugin handles.
+	Types() []string
+	// Validate checks an experiment whose fault has one of the plugin's types. Errors name
+	// the offending field relative to the experiment, e.g. "fault.delay is required".
+	Validate(exp domainconfig.Experiment) error
+	// Inject applies spec to the container. The outcome is returned even on error.
+	Inject(ctx context.Context, containerID string, spec domainconfig.Fault) (Outcome, error)
+	// Revert removes any fault of the plugin from the container. It returns nil when there
+	// is nothing to revert.
+	Revert(ctx context.Context, containerID string) error
+	// Describe summarises a successful injection for the experiment result.
+	Describe(containerID string, spec domainconfig.Fault, outcome Outcome) string
+	// RunnerReverts reports whether the fault stays in place once Inject returns, so the
+	// runner reverts it after fault.duration.
+	RunnerReverts() bool
+}
+
+// Outcome is what an injection observed beyond success or failure.
+type Outcome struct {
+	OOMKilled        bool // Docker reported an OOM kill after a memory-stress fault
+	ConnectionsReset int  // established connections destroyed by a tcp-reset fault
+	ProcessesKilled  int  // processes signalled by a process-kill fault
+}
+
+// Registry maps fault types to the plugins implementing them.
+type Registry struct {
+	mu      sync.RWMutex
+	byType  map[string]Plugin
+	plugins []Plugin
+}
+
+func NewRegistry(plugins ...Plugin) (*Registry, error) {
+	r := &Registry{byType: make(map[string]Plugin)}
+	for _, p := range plugins {
+		if err := r.Register(p); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a plugin for all of its types. No type is registered if any of them is
+// already taken.
+func (r *Registry) Register(p Plugin) error {
+	types := p.Types()
+	if len(types) == 0 {
+		return fmt.Errorf("plugin %T handles no fault types", p)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range types {
+		if strings.TrimSpace(t) == "" {
+			return fmt.Errorf("plugin %T has an empty fault type", p)
+		}
+		if _, exists := r.byType[t]; exists {
+			return fmt.Errorf("%w: %q", ErrDuplicateFaultType, t)
+		}
+	}
+	for _, t := range types {
+		r.byType[t] = p
+	}
+	r.plugins = append(r.plugins, p)
+	return nil
+}
+
+func (r *Registry) Lookup(faultType string) (Plugin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.byType[faultType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFaultType, faultType)
+	}
+	return p, nil
+}
+
+// Types returns every registered fault type in registration order.
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var types []string
+	for _, p := range r.plugins {
+		types = append(types, p.Types()...)
+	}
+	return types
+}
+
+// Plugins returns the registered plugins in registration order.
+func (r *Registry) Plugins() []Plugin {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Plugin(nil), r.plugins...)
+}
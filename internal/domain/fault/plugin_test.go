@@ -0,0 +1,99 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+type stubPlugin struct {
+	types []string
+}
+
+func (s *stubPlugin) Types() []string { return s.types }
+
+func (s *stubPlugin) RunnerReverts() bool { return false }
+
+func (s *stubPlugin) Validate(domainconfig.Experiment) error { return nil }
+
+func (s *stubPlugin) Inject(context.Context, string, domainconfig.Fault) (Outcome, error) {
+	return Outcome{}, nil
+}
+
+func (s *stubPlugin) Revert(context.Context, string) error { return nil }
+
+func (s *stubPlugin) Describe(string, domainconfig.Fault, Outcome) string { return "" }
+
+func TestRegistry_LookupAndOrder(t *testing.T) {
+	first := &stubPlugin{types: []string{"a", "b"}}
+	second := &stubPlugin{types: []string{"c"}}
+
+	registry, err := NewRegistry(first, second)
+	if err != nil {
+		t.Fatalf("NewRegistry returned error: %v", err)
+	}
+
+	if p, err := registry.Lookup("b"); err != nil || p != first {
+		t.Fatalf("expected b to resolve to the first plugin, got %v, %v", p, err)
+	}
+	if _, err := registry.Lookup("missing"); !errors.Is(err, ErrUnknownFaultType) {
+		t.Fatalf("expected ErrUnknownFaultType, got %v", err)
+	}
+	if got := registry.Types(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("expected types in registration order, got %v", got)
+	}
+	if got := registry.Plugins(); len(got) != 2 || got[0] != first || got[1] != second {
+		t.Fatalf("expected both plugins in registration order, got %v", got)
+	}
+}
+
+func TestRegistry_RejectsDuplicateTypes(t *testing.T) {
+	registry, err := NewRegistry(&stubPlugin{types: []string{"a"}})
+	if err != nil {
+		t.Fatalf("NewRegistry returned error: %v", err)
+	}
+
+	if err := registry.Register(&stubPlugin{types: []string{"z", "a"}}); !errors.Is(err, ErrDuplicateFaultType) {
+		t.Fatalf("expected ErrDuplicateFaultType, got %v", err)
+	}
+	if _, err := registry.Lookup("z"); err == nil {
+		t.Fatalf("expected a rejected plugin to register none of its types")
+	}
+}
+
+func TestBuiltinRegistry_PauseRevertsFirst(t *testing.T) {
+	registry, err := NewBuiltinRegistry(Injectors{})
+	if err != nil {
+		t.Fatalf("NewBuiltinRegistry returned error: %v", err)
+	}
+
+	plugins := registry.Plugins()
+	if got := plugins[0].Types(); !reflect.DeepEqual(got, []string{"pause"}) {
+		t.Fatalf("expected pause to be registered first, got %v", got)
+	}
+	if _, err := registry.Lookup("network-latency"); err != nil {
+		t.Fatalf("expected network-latency to be built in: %v", err)
+	}
+}
+
+func TestBuiltinPlugins_DistinctTypes(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, p := range BuiltinPlugins(Injectors{}) {
+		if len(p.Types()) == 0 {
+			t.Fatalf("built-in plugin %T handles no fault types", p)
+		}
+		for _, faultType := range p.Types() {
+			if faultType == "" || seen[faultType] {
+				t.Fatalf("built-in fault type %q is empty or registered twice", faultType)
+			}
+			seen[faultType] = true
+		}
+	}
+
+	if _, err := NewRegistry(BuiltinPlugins(Injectors{})...); err != nil {
+		t.Fatalf("registering the built-in plugins failed: %v", err)
+	}
+}
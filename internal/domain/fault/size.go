@@ -0,0 +1,27 @@
+package fault
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSizeOrPercent parses either an absolute size such as "256mb" or a percentage such as
+// "75%". Exactly one of the results is non-zero.
+func ParseSizeOrPercent(raw string) (uint64, float64, error) {
+	if strings.HasSuffix(strings.TrimSpace(raw), "%") {
+		percent, err := ParsePercent(raw)
+		if err != nil {
+			return 0, 0, err
+		}
+		if percent == 0 {
+			return 0, 0, fmt.Errorf("%w: %q", ErrInvalidPercentage, raw)
+		}
+		return 0, percent, nil
+	}
+
+	size, err := ParseSize(raw)
+	if err != nil {
+		return 0, 0, err
+	}
+	return size, 0, nil
+}
@@ -0,0 +1,25 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSizeOrPercent(t *testing.T) {
+	size, percent, err := ParseSizeOrPercent("256mb")
+	if err != nil || size != 256*1024*1024 || percent != 0 {
+		t.Fatalf("ParseSizeOrPercent(256mb) = %d, %g, %v", size, percent, err)
+	}
+
+	size, percent, err = ParseSizeOrPercent("75%")
+	if err != nil || size != 0 || percent != 75 {
+		t.Fatalf("ParseSizeOrPercent(75%%) = %d, %g, %v", size, percent, err)
+	}
+
+	if _, _, err := ParseSizeOrPercent("0%"); !errors.Is(err, ErrInvalidPercentage) {
+		t.Fatalf("ParseSizeOrPercent(0%%): expected ErrInvalidPercentage, got %v", err)
+	}
+	if _, _, err := ParseSizeOrPercent("lots"); !errors.Is(err, ErrInvalidSize) {
+		t.Fatalf("ParseSizeOrPercent(lots): expected ErrInvalidSize, got %v", err)
+	}
+}
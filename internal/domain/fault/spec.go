@@ -0,0 +1,686 @@
+package fault
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+func parseLatency(f domainconfig.Fault) (Latency, error) {
+	delay, err := time.ParseDuration(f.Delay)
+	if err != nil {
+		return Latency{}, fmt.Errorf("delay %q: %w", f.Delay, err)
+	}
+
+	latency := Latency{
+		Delay:        delay,
+		Distribution: strings.ToLower(strings.TrimSpace(f.Distribution)),
+	}
+	if strings.TrimSpace(f.Jitter) != "" {
+		latency.Jitter, err = time.ParseDuration(f.Jitter)
+		if err != nil {
+			return Latency{}, fmt.Errorf("jitter %q: %w", f.Jitter, err)
+		}
+	}
+	if strings.TrimSpace(f.Correlation) != "" {
+		latency.Correlation, err = ParsePercent(f.Correlation)
+		if err != nil {
+			return Latency{}, fmt.Errorf("correlation %q: %w", f.Correlation, err)
+		}
+	}
+
+	return latency, nil
+}
+
+func describeLatency(l Latency) string {
+	if l.Jitter <= 0 {
+		return l.Delay.String()
+	}
+
+	out := fmt.Sprintf("%s±%s", l.Delay, l.Jitter)
+	var details []string
+	if l.Correlation > 0 {
+		details = append(details, fmt.Sprintf("%g%% correlation", l.Correlation))
+	}
+	if l.Distribution != "" {
+		details = append(details, l.Distribution+" distribution")
+	}
+	if len(details) > 0 {
+		out += " (" + strings.Join(details, ", ") + ")"
+	}
+	return out
+}
+
+// parseImpairment builds the netem options for network-corrupt, network-duplicate and
+// network-reorder, including the optional delay they are combined with.
+func parseImpairment(f domainconfig.Fault) (Netem, error) {
+	netem := Netem{Direction: parseDirection(f), To: parseDestination(f)}
+
+	if strings.TrimSpace(f.Delay) != "" {
+		delay, err := time.ParseDuration(f.Delay)
+		if err != nil {
+			return Netem{}, fmt.Errorf("delay %q: %w", f.Delay, err)
+		}
+		netem.Latency = &Latency{Delay: delay}
+	}
+
+	raw := map[string]string{
+		"network-corrupt":   f.Corrupt,
+		"network-duplicate": f.Duplicate,
+		"network-reorder":   f.Reorder,
+	}[f.Type]
+	probability, err := parseProbability(raw, f.Correlation)
+	if err != nil {
+		return Netem{}, err
+	}
+
+	switch f.Type {
+	case "network-corrupt":
+		netem.Corrupt = &probability
+	case "network-duplicate":
+		netem.Duplicate = &probability
+	case "network-reorder":
+		netem.Reorder = &probability
+	default:
+		return Netem{}, fmt.Errorf("fault type %q is not a netem impairment", f.Type)
+	}
+
+	return netem, nil
+}
+
+func parseNetworkImpairment(n domainconfig.NetworkImpairment) (Netem, error) {
+	var netem Netem
+
+	if strings.TrimSpace(n.Delay) != "" {
+		latency, err := parseLatency(domainconfig.Fault{
+			Delay:        n.Delay,
+			Jitter:       n.Jitter,
+			Distribution: n.Distribution,
+		})
+		if err != nil {
+			return Netem{}, err
+		}
+		netem.Latency = &latency
+	}
+
+	probabilities := []struct {
+		raw    string
+		target **Probability
+	}{
+		{n.Loss, &netem.Loss},
+		{n.Corrupt, &netem.Corrupt},
+		{n.Duplicate, &netem.Duplicate},
+		{n.Reorder, &netem.Reorder},
+	}
+	for _, p := range probabilities {
+		if strings.TrimSpace(p.raw) == "" {
+			continue
+		}
+		probability, err := parseProbability(p.raw, "")
+		if err != nil {
+			return Netem{}, err
+		}
+		*p.target = &probability
+	}
+
+	if strings.TrimSpace(n.Rate) != "" {
+		rate, err := ParseRate(n.Rate)
+		if err != nil {
+			return Netem{}, fmt.Errorf("rate %q: %w", n.Rate, err)
+		}
+		netem.Rate = rate
+	}
+
+	return netem, nil
+}
+
+func describeNetem(n Netem) string {
+	var parts []string
+	if n.Latency != nil {
+		parts = append(parts, describeLatency(*n.Latency)+" delay")
+	}
+	if n.Loss != nil {
+		parts = append(parts, describeProbability(*n.Loss)+" packet loss")
+	}
+	if n.Corrupt != nil {
+		parts = append(parts, describeProbability(*n.Corrupt)+" packet corruption")
+	}
+	if n.Duplicate != nil {
+		parts = append(parts, describeProbability(*n.Duplicate)+" packet duplication")
+	}
+	if n.Reorder != nil {
+		parts = append(parts, describeProbability(*n.Reorder)+" packet reordering")
+	}
+	if n.Rate > 0 {
+		parts = append(parts, describeRate(n.Rate)+" rate limit")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func parsePortBlackhole(f domainconfig.Fault) PortBlackhole {
+	protocols := make([]string, 0, len(f.Protocols))
+	for _, protocol := range f.Protocols {
+		protocols = append(protocols, strings.ToLower(strings.TrimSpace(protocol)))
+	}
+	if len(protocols) == 0 {
+		protocols = []string{"tcp"}
+	}
+
+	direction := strings.ToLower(strings.TrimSpace(f.Direction))
+	if direction == "" {
+		direction = "both"
+	}
+
+	return PortBlackhole{
+		Ports:     f.Ports,
+		Protocols: protocols,
+		Direction: direction,
+		Reject:    strings.EqualFold(strings.TrimSpace(f.Action), "reject"),
+	}
+}
+
+func describePortBlackhole(target string, b PortBlackhole) string {
+	ports := make([]string, 0, len(b.Ports))
+	for _, port := range b.Ports {
+		ports = append(ports, fmt.Sprintf("%d", port))
+	}
+
+	verb := "dropped"
+	if b.Reject {
+		verb = "rejected"
+	}
+	return fmt.Sprintf("%s %s %s traffic on port(s) %s in %s",
+		verb, b.Direction, strings.Join(b.Protocols, "/"), strings.Join(ports, ", "), target)
+}
+
+func parseDNSFault(f domainconfig.Fault) (DNSFault, error) {
+	dnsFault := DNSFault{
+		Mode:    strings.ToLower(strings.TrimSpace(f.Mode)),
+		Domains: f.Domains,
+	}
+
+	var err error
+	if dnsFault.Mode == "delay" {
+		dnsFault.Delay, err = time.ParseDuration(f.Delay)
+		if err != nil {
+			return DNSFault{}, fmt.Errorf("delay %q: %w", f.Delay, err)
+		}
+	}
+	dnsFault.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return DNSFault{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return dnsFault, nil
+}
+
+func describeDNSFault(target string, f DNSFault) string {
+	domains := "all domains"
+	if len(f.Domains) > 0 {
+		domains = strings.Join(f.Domains, ", ")
+	}
+
+	if f.Mode == "delay" {
+		return fmt.Sprintf("delayed dns lookups of %s by %s in %s for %s", domains, f.Delay, target, f.Duration)
+	}
+	return fmt.Sprintf("answered dns lookups of %s with %s in %s for %s", domains, strings.ToUpper(f.Mode), target, f.Duration)
+}
+
+func parseCPUStress(f domainconfig.Fault) (CPUStress, error) {
+	stress := CPUStress{Workers: f.Workers, Load: 100}
+	if stress.Workers == 0 {
+		stress.Workers = 1
+	}
+
+	var err error
+	if strings.TrimSpace(f.Load) != "" {
+		stress.Load, err = ParsePercent(f.Load)
+		if err != nil {
+			return CPUStress{}, fmt.Errorf("load %q: %w", f.Load, err)
+		}
+	}
+	stress.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return CPUStress{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return stress, nil
+}
+
+func parseMemoryStress(f domainconfig.Fault) (MemoryStress, error) {
+	stress := MemoryStress{OOM: f.OOM}
+
+	var err error
+	if !f.OOM {
+		stress.Bytes, stress.LimitPercent, err = ParseSizeOrPercent(f.Size)
+		if err != nil {
+			return MemoryStress{}, fmt.Errorf("size %q: %w", f.Size, err)
+		}
+	}
+	stress.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return MemoryStress{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return stress, nil
+}
+
+func describeMemoryStress(target string, s MemoryStress, oomKilled bool) string {
+	var out string
+	switch {
+	case s.OOM && !oomKilled:
+		// Swap the allocation did not fill, or a reclaim that kept up, leaves the limit intact.
+		return fmt.Sprintf("allocated past the memory limit of %s for %s, but the limit was not crossed (docker reported no OOM kill)", target, s.Duration)
+	case s.OOM:
+		out = fmt.Sprintf("pushed %s past its memory limit for %s", target, s.Duration)
+	case s.LimitPercent > 0:
+		out = fmt.Sprintf("allocated %g%% of the memory limit in %s for %s", s.LimitPercent, target, s.Duration)
+	default:
+		out = fmt.Sprintf("allocated %s in %s for %s", describeBytes(s.Bytes), target, s.Duration)
+	}
+	if oomKilled {
+		out += " (docker reported OOMKilled)"
+	}
+	return out
+}
+
+func parseIOThrottle(f domainconfig.Fault) (IOThrottle, error) {
+	throttle := IOThrottle{
+		Device:    strings.TrimSpace(f.Device),
+		ReadIOPS:  f.ReadIops,
+		WriteIOPS: f.WriteIops,
+	}
+
+	var err error
+	if strings.TrimSpace(f.ReadBps) != "" {
+		throttle.ReadBPS, err = ParseSize(f.ReadBps)
+		if err != nil {
+			return IOThrottle{}, fmt.Errorf("readBps %q: %w", f.ReadBps, err)
+		}
+	}
+	if strings.TrimSpace(f.WriteBps) != "" {
+		throttle.WriteBPS, err = ParseSize(f.WriteBps)
+		if err != nil {
+			return IOThrottle{}, fmt.Errorf("writeBps %q: %w", f.WriteBps, err)
+		}
+	}
+	throttle.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return IOThrottle{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return throttle, nil
+}
+
+func describeIOThrottle(target string, t IOThrottle) string {
+	var limits []string
+	if t.ReadBPS > 0 {
+		limits = append(limits, describeBytes(t.ReadBPS)+"/s read")
+	}
+	if t.WriteBPS > 0 {
+		limits = append(limits, describeBytes(t.WriteBPS)+"/s write")
+	}
+	if t.ReadIOPS > 0 {
+		limits = append(limits, fmt.Sprintf("%d read iops", t.ReadIOPS))
+	}
+	if t.WriteIOPS > 0 {
+		limits = append(limits, fmt.Sprintf("%d write iops", t.WriteIOPS))
+	}
+	return fmt.Sprintf("throttled %s on %s to %s for %s", target, t.Device, strings.Join(limits, ", "), t.Duration)
+}
+
+func parseHTTPFault(f domainconfig.Fault) (HTTPFault, error) {
+	httpFault := HTTPFault{
+		Ports:       f.Ports,
+		Percent:     100,
+		AbortStatus: f.Abort,
+	}
+	for _, route := range f.Routes {
+		httpFault.Routes = append(httpFault.Routes, HTTPRoute{
+			Method: strings.ToUpper(strings.TrimSpace(route.Method)),
+			Path:   strings.TrimSpace(route.Path),
+		})
+	}
+
+	var err error
+	if strings.TrimSpace(f.Percentage) != "" {
+		httpFault.Percent, err = ParsePercent(f.Percentage)
+		if err != nil {
+			return HTTPFault{}, fmt.Errorf("percentage %q: %w", f.Percentage, err)
+		}
+	}
+	if strings.TrimSpace(f.Delay) != "" {
+		httpFault.Delay, err = parseLatency(f)
+		if err != nil {
+			return HTTPFault{}, err
+		}
+	}
+	httpFault.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return HTTPFault{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return httpFault, nil
+}
+
+// describeHTTPFault renders e.g. "returned 503 for 10% of POST /orders* on api:8080 for 5m0s".
+func describeHTTPFault(target string, f HTTPFault) string {
+	var actions []string
+	if f.Delay.Delay > 0 {
+		actions = append(actions, "delayed by "+describeLatency(f.Delay))
+	}
+	if f.AbortStatus != 0 {
+		actions = append(actions, fmt.Sprintf("returned %d", f.AbortStatus))
+	}
+
+	requests := "all requests"
+	if len(f.Routes) > 0 {
+		routes := make([]string, 0, len(f.Routes))
+		for _, route := range f.Routes {
+			method, path := route.Method, route.Path
+			if method == "" {
+				method = "any method"
+			}
+			if path == "" {
+				path = "/*"
+			}
+			routes = append(routes, method+" "+path)
+		}
+		requests = strings.Join(routes, ", ")
+	}
+
+	ports := make([]string, 0, len(f.Ports))
+	for _, port := range f.Ports {
+		ports = append(ports, strconv.Itoa(port))
+	}
+
+	return fmt.Sprintf("%s for %g%% of %s on %s:%s for %s",
+		strings.Join(actions, " and "), f.Percent, requests, target, strings.Join(ports, ","), f.Duration)
+}
+
+func parseClockSkew(f domainconfig.Fault) (ClockSkew, error) {
+	var skew ClockSkew
+
+	var err error
+	skew.Offset, err = time.ParseDuration(strings.TrimSpace(f.Offset))
+	if err != nil {
+		return ClockSkew{}, fmt.Errorf("offset %q: %w", f.Offset, err)
+	}
+	skew.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return ClockSkew{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return skew, nil
+}
+
+// describeOffset formats an offset with an explicit sign, e.g. +2h0m0s or -90ms.
+func describeOffset(offset time.Duration) string {
+	if offset < 0 {
+		return offset.String()
+	}
+	return "+" + offset.String()
+}
+
+func describeProcessKill(target string, kill ProcessKill, count int) string {
+	signal := kill.Signal
+	if signal == "" {
+		signal = "SIGKILL"
+	}
+
+	var criteria []string
+	if kill.Name != "" {
+		criteria = append(criteria, "named "+kill.Name)
+	}
+	if kill.Cmdline != "" {
+		criteria = append(criteria, fmt.Sprintf("matching %q", kill.Cmdline))
+	}
+
+	return fmt.Sprintf("sent %s to %d process(es) %s in %s", signal, count, strings.Join(criteria, " and "), target)
+}
+
+func parseResourceExhaustion(f domainconfig.Fault) (ResourceExhaustion, error) {
+	exhaustion := ResourceExhaustion{
+		Resource: strings.TrimSpace(f.Resource),
+		Count:    f.Count,
+	}
+	if strings.TrimSpace(f.Percentage) != "" {
+		percent, err := ParsePercent(f.Percentage)
+		if err != nil {
+			return ResourceExhaustion{}, fmt.Errorf("percentage %q: %w", f.Percentage, err)
+		}
+		exhaustion.Percent = percent
+	}
+
+	duration, err := time.ParseDuration(f.Duration)
+	if err != nil {
+		return ResourceExhaustion{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+	exhaustion.Duration = duration
+	return exhaustion, nil
+}
+
+// describeResourceExhaustion renders e.g. "filled 90% of the pids limit of api for 1m0s" or
+// "held 5000 open socket(s) in api for 1m0s".
+func describeResourceExhaustion(target string, e ResourceExhaustion) string {
+	switch {
+	case e.Resource == "pids" && e.Count > 0:
+		return fmt.Sprintf("added %d idle process(es) to %s for %s", e.Count, target, e.Duration)
+	case e.Resource == "pids":
+		return fmt.Sprintf("filled %s%% of the pids limit of %s for %s", strconv.FormatFloat(e.Percent, 'f', -1, 64), target, e.Duration)
+	case e.Count > 0:
+		return fmt.Sprintf("held %d open socket(s) in %s for %s", e.Count, target, e.Duration)
+	default:
+		return fmt.Sprintf("held sockets for %s%% of the open-file limit of %s for %s", strconv.FormatFloat(e.Percent, 'f', -1, 64), target, e.Duration)
+	}
+}
+
+func parseTCPReset(f domainconfig.Fault) (TCPReset, error) {
+	reset := TCPReset{Match: Destination{Hosts: f.Peers, Ports: f.Ports}}
+	if strings.TrimSpace(f.Rate) != "" {
+		rate, err := ParseFrequency(f.Rate)
+		if err != nil {
+			return TCPReset{}, fmt.Errorf("rate %q: %w", f.Rate, err)
+		}
+		reset.Rate = rate
+	}
+
+	duration, err := time.ParseDuration(f.Duration)
+	if err != nil {
+		return TCPReset{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+	reset.Duration = duration
+	return reset, nil
+}
+
+// describeTCPReset renders e.g. "reset 12 established connection(s) of api with postgres on
+// port 5432 at 0.5/s for 30s".
+func describeTCPReset(target string, reset TCPReset, count int) string {
+	out := fmt.Sprintf("reset %d established connection(s) of %s", count, target)
+	if len(reset.Match.Hosts) > 0 {
+		out += " with " + strings.Join(reset.Match.Hosts, ", ")
+	}
+	if len(reset.Match.Ports) > 0 {
+		ports := make([]string, 0, len(reset.Match.Ports))
+		for _, port := range reset.Match.Ports {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		out += " on port " + strings.Join(ports, ", ")
+	}
+	if reset.Rate > 0 {
+		out += " at " + strconv.FormatFloat(reset.Rate, 'g', 4, 64) + "/s"
+	}
+	return out + " for " + reset.Duration.String()
+}
+
+func parseRestartLoop(f domainconfig.Fault) (RestartLoop, error) {
+	loop := RestartLoop{Count: f.Count}
+	if strings.TrimSpace(f.Interval) != "" {
+		interval, err := time.ParseDuration(f.Interval)
+		if err != nil {
+			return RestartLoop{}, fmt.Errorf("interval %q: %w", f.Interval, err)
+		}
+		loop.Interval = interval
+	}
+
+	return loop, nil
+}
+
+func parseDiskFill(f domainconfig.Fault) (DiskFill, error) {
+	fill := DiskFill{
+		Path:   strings.TrimSpace(f.Path),
+		Volume: strings.TrimSpace(f.Volume),
+	}
+
+	var err error
+	fill.Bytes, fill.Percent, err = ParseSizeOrPercent(f.Size)
+	if err != nil {
+		return DiskFill{}, fmt.Errorf("size %q: %w", f.Size, err)
+	}
+	fill.Duration, err = time.ParseDuration(f.Duration)
+	if err != nil {
+		return DiskFill{}, fmt.Errorf("duration %q: %w", f.Duration, err)
+	}
+
+	return fill, nil
+}
+
+func describeDiskFill(target string, f DiskFill) string {
+	location := f.Path
+	if f.Volume != "" {
+		location = "volume " + f.Volume
+	}
+
+	if f.Percent > 0 {
+		return fmt.Sprintf("filled %s in %s to %g%% for %s", location, target, f.Percent, f.Duration)
+	}
+	return fmt.Sprintf("wrote %s of ballast to %s in %s for %s", describeBytes(f.Bytes), location, target, f.Duration)
+}
+
+func describeBytes(bytes uint64) string {
+	switch {
+	case bytes >= 1<<30 && bytes%(1<<30) == 0:
+		return fmt.Sprintf("%dGiB", bytes>>30)
+	case bytes >= 1<<20 && bytes%(1<<20) == 0:
+		return fmt.Sprintf("%dMiB", bytes>>20)
+	case bytes >= 1<<10 && bytes%(1<<10) == 0:
+		return fmt.Sprintf("%dKiB", bytes>>10)
+	default:
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+}
+
+func describeRate(bitsPerSecond uint64) string {
+	rate := float64(bitsPerSecond)
+	switch {
+	case rate >= 1e9:
+		return fmt.Sprintf("%ggbit", rate/1e9)
+	case rate >= 1e6:
+		return fmt.Sprintf("%gmbit", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%gkbit", rate/1e3)
+	default:
+		return fmt.Sprintf("%gbit", rate)
+	}
+}
+
+func parseBandwidth(f domainconfig.Fault) (Bandwidth, error) {
+	rate, err := ParseRate(f.Rate)
+	if err != nil {
+		return Bandwidth{}, fmt.Errorf("rate %q: %w", f.Rate, err)
+	}
+
+	bandwidth := Bandwidth{Rate: rate, Direction: parseDirection(f)}
+	if strings.TrimSpace(f.Burst) != "" {
+		bandwidth.Burst, err = ParseSize(f.Burst)
+		if err != nil {
+			return Bandwidth{}, fmt.Errorf("burst %q: %w", f.Burst, err)
+		}
+	}
+	if strings.TrimSpace(f.Limit) != "" {
+		bandwidth.Limit, err = ParseSize(f.Limit)
+		if err != nil {
+			return Bandwidth{}, fmt.Errorf("limit %q: %w", f.Limit, err)
+		}
+	}
+
+	return bandwidth, nil
+}
+
+// parseDirection normalizes the shaping direction. An empty direction keeps the default
+// of shaping egress traffic only.
+func parseDirection(f domainconfig.Fault) string {
+	return strings.ToLower(strings.TrimSpace(f.Direction))
+}
+
+// parseDestination returns the optional to/ports scope of a netem fault.
+func parseDestination(f domainconfig.Fault) *Destination {
+	if len(f.To) == 0 && len(f.Ports) == 0 {
+		return nil
+	}
+	return &Destination{Hosts: f.To, Ports: f.Ports}
+}
+
+// isPlainEgress reports whether netem can be applied through the egress-only
+// InjectNetworkLatency and InjectPacketLoss operations.
+func isPlainEgress(netem Netem) bool {
+	return (netem.Direction == "" || netem.Direction == "egress") && netem.To == nil
+}
+
+// describeScope renders the direction or destination a netem fault is limited to.
+func describeScope(netem Netem) string {
+	if netem.To == nil {
+		return describeDirection(netem.Direction)
+	}
+
+	var parts []string
+	if len(netem.To.Hosts) > 0 {
+		parts = append(parts, strings.Join(netem.To.Hosts, ", "))
+	}
+	if len(netem.To.Ports) > 0 {
+		ports := make([]string, 0, len(netem.To.Ports))
+		for _, port := range netem.To.Ports {
+			ports = append(ports, fmt.Sprintf("%d", port))
+		}
+		parts = append(parts, "port(s) "+strings.Join(ports, ", "))
+	}
+	return " for traffic to " + strings.Join(parts, " on ")
+}
+
+func describeDirection(direction string) string {
+	switch direction {
+	case "ingress":
+		return " on ingress"
+	case "both":
+		return " on ingress and egress"
+	default:
+		return ""
+	}
+}
+
+func parseProbability(rawPercent string, rawCorrelation string) (Probability, error) {
+	percent, err := ParsePercent(rawPercent)
+	if err != nil {
+		return Probability{}, fmt.Errorf("percentage %q: %w", rawPercent, err)
+	}
+
+	var correlation float64
+	if strings.TrimSpace(rawCorrelation) != "" {
+		correlation, err = ParsePercent(rawCorrelation)
+		if err != nil {
+			return Probability{}, fmt.Errorf("correlation %q: %w", rawCorrelation, err)
+		}
+	}
+
+	return Probability{Percent: percent, Correlation: correlation}, nil
+}
+
+func describeProbability(p Probability) string {
+	if p.Correlation > 0 {
+		return fmt.Sprintf("%g%% (%g%% correlation)", p.Percent, p.Correlation)
+	}
+	return fmt.Sprintf("%g%%", p.Percent)
+}
@@ -0,0 +1,503 @@
+package fault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+func validateJitter(f domainconfig.Fault) error {
+	var jitter time.Duration
+	if f.Jitter != "" {
+		var err error
+		jitter, err = time.ParseDuration(f.Jitter)
+		if err != nil {
+			return fmt.Errorf("fault.jitter must be a valid duration: %w", err)
+		}
+		if jitter < 0 {
+			return fmt.Errorf("fault.jitter must be zero or positive")
+		}
+	}
+
+	if f.Correlation != "" {
+		if jitter == 0 {
+			return fmt.Errorf("fault.correlation requires fault.jitter for %s", f.Type)
+		}
+		if _, err := ParsePercent(f.Correlation); err != nil {
+			return fmt.Errorf("fault.correlation must be a valid percentage: %w", err)
+		}
+	}
+
+	if f.Distribution != "" {
+		if jitter == 0 {
+			return fmt.Errorf("fault.distribution requires fault.jitter for %s", f.Type)
+		}
+		if !isSupportedDistribution(f.Distribution) {
+			return fmt.Errorf("fault.distribution %q is not supported", f.Distribution)
+		}
+	}
+
+	return nil
+}
+
+// validateImpairment checks the corrupt, duplicate and reorder faults, which take one
+// percentage and may be combined with a delay in the same netem qdisc.
+func validateImpairment(f domainconfig.Fault) error {
+	field, value := impairmentField(f)
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("fault.%s is required for %s", field, f.Type)
+	}
+	percent, err := ParsePercent(value)
+	if err != nil {
+		return fmt.Errorf("fault.%s must be a valid percentage: %w", field, err)
+	}
+	if percent == 0 {
+		return fmt.Errorf("fault.%s must be greater than zero", field)
+	}
+	if f.Correlation != "" {
+		if _, err := ParsePercent(f.Correlation); err != nil {
+			return fmt.Errorf("fault.correlation must be a valid percentage: %w", err)
+		}
+	}
+
+	if strings.TrimSpace(f.Delay) == "" {
+		if f.Type == "network-reorder" {
+			return fmt.Errorf("fault.delay is required for network-reorder")
+		}
+		return nil
+	}
+	delay, err := time.ParseDuration(f.Delay)
+	if err != nil {
+		return fmt.Errorf("fault.delay must be a valid duration: %w", err)
+	}
+	if delay <= 0 {
+		return fmt.Errorf("fault.delay must be greater than zero")
+	}
+
+	return nil
+}
+
+func validateNetworkImpairment(n domainconfig.NetworkImpairment) error {
+	var delay, jitter time.Duration
+	var err error
+
+	if n.Delay != "" {
+		delay, err = time.ParseDuration(n.Delay)
+		if err != nil {
+			return fmt.Errorf("delay must be a valid duration: %w", err)
+		}
+		if delay <= 0 {
+			return fmt.Errorf("delay must be greater than zero")
+		}
+	}
+	if n.Jitter != "" {
+		if delay == 0 {
+			return fmt.Errorf("jitter requires delay")
+		}
+		jitter, err = time.ParseDuration(n.Jitter)
+		if err != nil {
+			return fmt.Errorf("jitter must be a valid duration: %w", err)
+		}
+		if jitter < 0 {
+			return fmt.Errorf("jitter must be zero or positive")
+		}
+	}
+	if n.Distribution != "" {
+		if jitter == 0 {
+			return fmt.Errorf("distribution requires jitter")
+		}
+		if !isSupportedDistribution(n.Distribution) {
+			return fmt.Errorf("distribution %q is not supported", n.Distribution)
+		}
+	}
+
+	percentages := []struct {
+		field string
+		value string
+	}{
+		{"loss", n.Loss},
+		{"corrupt", n.Corrupt},
+		{"duplicate", n.Duplicate},
+		{"reorder", n.Reorder},
+	}
+	for _, p := range percentages {
+		if p.value == "" {
+			continue
+		}
+		percent, err := ParsePercent(p.value)
+		if err != nil {
+			return fmt.Errorf("%s must be a valid percentage: %w", p.field, err)
+		}
+		if percent == 0 {
+			return fmt.Errorf("%s must be greater than zero", p.field)
+		}
+	}
+	if n.Reorder != "" && delay == 0 {
+		return fmt.Errorf("reorder requires delay")
+	}
+
+	if n.Rate != "" {
+		if _, err := ParseRate(n.Rate); err != nil {
+			return fmt.Errorf("rate must be a valid rate: %w", err)
+		}
+	}
+
+	if n == (domainconfig.NetworkImpairment{}) {
+		return fmt.Errorf("requires at least one impairment")
+	}
+
+	return nil
+}
+
+func validatePortBlackhole(f domainconfig.Fault) error {
+	if len(f.Ports) == 0 {
+		return fmt.Errorf("fault.ports is required for port-blackhole")
+	}
+	for j, port := range f.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("fault.ports[%d] must be between 1 and 65535", j)
+		}
+	}
+	for j, protocol := range f.Protocols {
+		switch strings.ToLower(strings.TrimSpace(protocol)) {
+		case "tcp", "udp":
+		default:
+			return fmt.Errorf("fault.protocols[%d] %q is not supported", j, protocol)
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(f.Action)) {
+	case "", "drop", "reject":
+	default:
+		return fmt.Errorf("fault.action %q is not supported", f.Action)
+	}
+	if !isSupportedDirection(f.Direction) {
+		return fmt.Errorf("fault.direction %q is not supported", f.Direction)
+	}
+	return nil
+}
+
+func validateDNS(f domainconfig.Fault) error {
+	switch strings.ToLower(strings.TrimSpace(f.Mode)) {
+	case "nxdomain", "servfail":
+	case "delay":
+		if strings.TrimSpace(f.Delay) == "" {
+			return fmt.Errorf("fault.delay is required for dns mode delay")
+		}
+		delay, err := time.ParseDuration(f.Delay)
+		if err != nil {
+			return fmt.Errorf("fault.delay must be a valid duration: %w", err)
+		}
+		if delay <= 0 {
+			return fmt.Errorf("fault.delay must be greater than zero")
+		}
+	case "":
+		return fmt.Errorf("fault.mode is required for dns")
+	default:
+		return fmt.Errorf("fault.mode %q is not supported", f.Mode)
+	}
+	for j, domain := range f.Domains {
+		if strings.TrimSpace(domain) == "" {
+			return fmt.Errorf("fault.domains[%d] must not be empty", j)
+		}
+	}
+	return validateDuration(f, true)
+}
+
+func validateCPUStress(f domainconfig.Fault) error {
+	if f.Workers < 0 {
+		return fmt.Errorf("fault.workers must be zero or positive")
+	}
+	if strings.TrimSpace(f.Load) != "" {
+		load, err := ParsePercent(f.Load)
+		if err != nil {
+			return fmt.Errorf("fault.load must be a valid percentage: %w", err)
+		}
+		if load == 0 {
+			return fmt.Errorf("fault.load must be greater than zero")
+		}
+	}
+	return validateDuration(f, true)
+}
+
+func validateMemoryStress(f domainconfig.Fault) error {
+	if strings.TrimSpace(f.Size) == "" {
+		if !f.OOM {
+			return fmt.Errorf("fault.size is required for memory-stress unless fault.oom is set")
+		}
+	} else if f.OOM {
+		return fmt.Errorf("fault.size and fault.oom are mutually exclusive")
+	} else if _, _, err := ParseSizeOrPercent(f.Size); err != nil {
+		return fmt.Errorf("fault.size must be a valid size or percentage: %w", err)
+	}
+	return validateDuration(f, true)
+}
+
+func validateIOThrottle(f domainconfig.Fault) error {
+	if strings.TrimSpace(f.Device) == "" {
+		return fmt.Errorf("fault.device is required for io-throttle")
+	}
+	if f.ReadBps == "" && f.WriteBps == "" && f.ReadIops == 0 && f.WriteIops == 0 {
+		return fmt.Errorf("fault.readBps, fault.writeBps, fault.readIops or fault.writeIops is required for io-throttle")
+	}
+	if f.ReadBps != "" {
+		if _, err := ParseSize(f.ReadBps); err != nil {
+			return fmt.Errorf("fault.readBps must be a valid size: %w", err)
+		}
+	}
+	if f.WriteBps != "" {
+		if _, err := ParseSize(f.WriteBps); err != nil {
+			return fmt.Errorf("fault.writeBps must be a valid size: %w", err)
+		}
+	}
+	return validateDuration(f, true)
+}
+
+func validateDiskFill(f domainconfig.Fault) error {
+	hasPath := strings.TrimSpace(f.Path) != ""
+	hasVolume := strings.TrimSpace(f.Volume) != ""
+	if hasPath == hasVolume {
+		return fmt.Errorf("exactly one of fault.path or fault.volume is required for disk-fill")
+	}
+	if hasPath && !strings.HasPrefix(strings.TrimSpace(f.Path), "/") {
+		return fmt.Errorf("fault.path must be absolute")
+	}
+	if strings.TrimSpace(f.Size) == "" {
+		return fmt.Errorf("fault.size is required for disk-fill")
+	}
+	if _, _, err := ParseSizeOrPercent(f.Size); err != nil {
+		return fmt.Errorf("fault.size must be a valid size or percentage: %w", err)
+	}
+	return validateDuration(f, true)
+}
+
+func validateHTTP(f domainconfig.Fault) error {
+	if len(f.Ports) == 0 {
+		return fmt.Errorf("fault.ports is required for http")
+	}
+	for j, port := range f.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("fault.ports[%d] must be between 1 and 65535", j)
+		}
+	}
+	for j, route := range f.Routes {
+		if path := strings.TrimSpace(route.Path); path != "" && !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("fault.routes[%d].path must start with /", j)
+		}
+	}
+	if f.Abort != 0 && (f.Abort < 100 || f.Abort > 599) {
+		return fmt.Errorf("fault.abort %d is not an HTTP status code", f.Abort)
+	}
+	if f.Abort == 0 && strings.TrimSpace(f.Delay) == "" {
+		return fmt.Errorf("fault.abort or fault.delay is required for http")
+	}
+	if strings.TrimSpace(f.Delay) != "" {
+		delay, err := time.ParseDuration(f.Delay)
+		if err != nil {
+			return fmt.Errorf("fault.delay must be a valid duration: %w", err)
+		}
+		if delay <= 0 {
+			return fmt.Errorf("fault.delay must be greater than zero")
+		}
+		if err := validateJitter(f); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(f.Percentage) != "" {
+		percent, err := ParsePercent(f.Percentage)
+		if err != nil {
+			return fmt.Errorf("fault.percentage must be a valid percentage: %w", err)
+		}
+		if percent == 0 {
+			return fmt.Errorf("fault.percentage must be greater than zero")
+		}
+	}
+	return validateDuration(f, true)
+}
+
+func validateResourceExhaustion(f domainconfig.Fault) error {
+	switch strings.TrimSpace(f.Resource) {
+	case "pids", "files":
+	case "":
+		return fmt.Errorf("fault.resource is required for resource-exhaustion")
+	default:
+		return fmt.Errorf("fault.resource %q must be pids or files", f.Resource)
+	}
+	hasPercentage := strings.TrimSpace(f.Percentage) != ""
+	if f.Count < 0 {
+		return fmt.Errorf("fault.count must be greater than zero")
+	}
+	if (f.Count > 0) == hasPercentage {
+		return fmt.Errorf("exactly one of fault.count or fault.percentage is required for resource-exhaustion")
+	}
+	if hasPercentage {
+		percent, err := ParsePercent(f.Percentage)
+		if err != nil {
+			return fmt.Errorf("fault.percentage must be a valid percentage: %w", err)
+		}
+		if percent == 0 {
+			return fmt.Errorf("fault.percentage must be greater than zero")
+		}
+	}
+	return validateDuration(f, true)
+}
+
+func validateTCPReset(f domainconfig.Fault) error {
+	if len(f.Peers) == 0 && len(f.Ports) == 0 {
+		return fmt.Errorf("fault.peers or fault.ports is required for tcp-reset")
+	}
+	for j, peer := range f.Peers {
+		if strings.TrimSpace(peer) == "" {
+			return fmt.Errorf("fault.peers[%d] must not be empty", j)
+		}
+	}
+	for j, port := range f.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("fault.ports[%d] must be between 1 and 65535", j)
+		}
+	}
+	if strings.TrimSpace(f.Rate) != "" {
+		if _, err := ParseFrequency(f.Rate); err != nil {
+			return fmt.Errorf("fault.rate must be a frequency such as 5/s: %w", err)
+		}
+	}
+	return validateDuration(f, true)
+}
+
+func validateClockSkew(f domainconfig.Fault) error {
+	if strings.TrimSpace(f.Offset) == "" {
+		return fmt.Errorf("fault.offset is required for clock-skew")
+	}
+	offset, err := time.ParseDuration(strings.TrimSpace(f.Offset))
+	if err != nil {
+		return fmt.Errorf("fault.offset must be a valid duration: %w", err)
+	}
+	if offset == 0 {
+		return fmt.Errorf("fault.offset must not be zero")
+	}
+	if offset%time.Second != 0 {
+		return fmt.Errorf("fault.offset must be a whole number of seconds")
+	}
+	return validateDuration(f, true)
+}
+
+func validateProcessMatch(f domainconfig.Fault) error {
+	if strings.TrimSpace(f.Process) == "" && strings.TrimSpace(f.Cmdline) == "" {
+		return fmt.Errorf("fault.process or fault.cmdline is required for process-kill")
+	}
+	if pattern := strings.TrimSpace(f.Cmdline); pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("fault.cmdline must be a valid regular expression: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateRestartLoop(f domainconfig.Fault) error {
+	if f.Count <= 0 {
+		return fmt.Errorf("fault.count must be greater than zero for restart-loop")
+	}
+	if strings.TrimSpace(f.Interval) == "" {
+		if f.Count > 1 {
+			return fmt.Errorf("fault.interval is required for restart-loop with more than one restart")
+		}
+		return nil
+	}
+	interval, err := time.ParseDuration(f.Interval)
+	if err != nil {
+		return fmt.Errorf("fault.interval must be a valid duration: %w", err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("fault.interval must be greater than zero")
+	}
+	return nil
+}
+
+func validateDuration(f domainconfig.Fault, required bool) error {
+	if strings.TrimSpace(f.Duration) == "" {
+		if required {
+			return fmt.Errorf("fault.duration is required for %s", f.Type)
+		}
+		return nil
+	}
+	duration, err := time.ParseDuration(f.Duration)
+	if err != nil {
+		return fmt.Errorf("fault.duration must be a valid duration: %w", err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("fault.duration must be greater than zero")
+	}
+	return nil
+}
+
+// validateDestination checks the optional to/ports scope of a netem fault.
+func validateDestination(f domainconfig.Fault) error {
+	if len(f.To) == 0 && len(f.Ports) == 0 {
+		return nil
+	}
+	if f.Type == "bandwidth" {
+		return fmt.Errorf("fault.to and fault.ports are not supported for bandwidth")
+	}
+	if direction := strings.ToLower(strings.TrimSpace(f.Direction)); direction != "" && direction != "egress" {
+		return fmt.Errorf("fault.to and fault.ports require direction egress")
+	}
+	for j, host := range f.To {
+		if strings.TrimSpace(host) == "" {
+			return fmt.Errorf("fault.to[%d] must not be empty", j)
+		}
+	}
+	for j, port := range f.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("fault.ports[%d] must be between 1 and 65535", j)
+		}
+	}
+	return nil
+}
+
+func isSupportedDirection(raw string) bool {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "ingress", "egress", "both":
+		return true
+	default:
+		return false
+	}
+}
+
+func impairmentField(f domainconfig.Fault) (string, string) {
+	switch f.Type {
+	case "network-corrupt":
+		return "corrupt", f.Corrupt
+	case "network-duplicate":
+		return "duplicate", f.Duplicate
+	default:
+		return "reorder", f.Reorder
+	}
+}
+
+func isSupportedDistribution(raw string) bool {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "normal", "pareto", "paretonormal":
+		return true
+	default:
+		return false
+	}
+}
+
+func isSupportedSignal(raw string) bool {
+	signal := strings.ToUpper(strings.TrimSpace(raw))
+	if signal == "" {
+		return true
+	}
+	if !strings.HasPrefix(signal, "SIG") {
+		signal = "SIG" + signal
+	}
+
+	switch signal {
+	case "SIGKILL", "SIGTERM", "SIGINT", "SIGQUIT", "SIGHUP", "SIGUSR1", "SIGUSR2":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+)
+
+var (
+	ErrEmptySelector       = errors.New("target selector needs labels, project, service, image or name")
+	ErrNoMatchingContainer = errors.New("no running container matches the target selector")
+)
+
+// Container is a running container as seen by target selectors.
+type Container struct {
+	ID     string
+	Name   string
+	Image  string
+	Labels map[string]string
+}
+
+// Lister returns the running containers a selector is resolved against.
+type Lister interface {
+	RunningContainers(ctx context.Context) ([]Container, error)
+}
+
+// Selector is a validated TargetSelector.
+type Selector struct {
+	labels map[string]string
+	image  *regexp.Regexp
+	name   *regexp.Regexp
+	raw    domainconfig.TargetSelector
+}
+
+// Compile validates sel. Errors name the offending field relative to the experiment.
+func Compile(sel domainconfig.TargetSelector) (*Selector, error) {
+	s := &Selector{labels: make(map[string]string), raw: sel}
+
+	for key, value := range sel.Labels {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("target.labels must not have an empty key")
+		}
+		s.labels[key] = strings.TrimSpace(value)
+	}
+	if project := strings.TrimSpace(sel.Project); project != "" {
+		s.labels[composeProjectLabel] = project
+	}
+	if service := strings.TrimSpace(sel.Service); service != "" {
+		s.labels[composeServiceLabel] = service
+	}
+
+	if image := strings.TrimSpace(sel.Image); image != "" {
+		s.image = compileGlob(image)
+	}
+	if name := strings.TrimSpace(sel.Name); name != "" {
+		re, err := regexp.Compile(name)
+		if err != nil {
+			return nil, fmt.Errorf("target.name must be a valid regular expression: %w", err)
+		}
+		s.name = re
+	}
+
+	if len(s.labels) == 0 && s.image == nil && s.name == nil {
+		return nil, fmt.Errorf("target: %w", ErrEmptySelector)
+	}
+	return s, nil
+}
+
+// Matches reports whether c satisfies every matcher of the selector.
+func (s *Selector) Matches(c Container) bool {
+	for key, want := range s.labels {
+		got, ok := c.Labels[key]
+		if !ok || (want != "" && got != want) {
+			return false
+		}
+	}
+	if s.image != nil && !s.image.MatchString(c.Image) {
+		return false
+	}
+	if s.name != nil && !s.name.MatchString(c.Name) {
+		return false
+	}
+	return true
+}
+
+// Filter returns the containers matching the selector, sorted by name.
+func (s *Selector) Filter(containers []Container) []Container {
+	var out []Container
+	for _, c := range containers {
+		if s.Matches(c) {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (s *Selector) String() string {
+	return Describe(s.raw)
+}
+
+// Describe renders a selector for logs and results, e.g. "service=api image=postgres:*".
+func Describe(sel domainconfig.TargetSelector) string {
+	var parts []string
+
+	keys := make([]string, 0, len(sel.Labels))
+	for key := range sel.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if sel.Labels[key] == "" {
+			parts = append(parts, "label "+key)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("label %s=%s", key, sel.Labels[key]))
+	}
+	if sel.Project != "" {
+		parts = append(parts, "project="+sel.Project)
+	}
+	if sel.Service != "" {
+		parts = append(parts, "service="+sel.Service)
+	}
+	if sel.Image != "" {
+		parts = append(parts, "image="+sel.Image)
+	}
+	if sel.Name != "" {
+		parts = append(parts, "name=~"+sel.Name)
+	}
+	return strings.Join(parts, " ")
+}
+
+// compileGlob turns an image glob into an anchored regular expression. Unlike path.Match,
+// "*" also matches "/", so "*postgres*" matches "docker.io/library/postgres:16".
+func compileGlob(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,67 @@
+package target
+
+import (
+	"errors"
+	"testing"
+
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+)
+
+func TestSelector_Filter(t *testing.T) {
+	containers := []Container{
+		{Name: "shop-api-2", Image: "ghcr.io/acme/api:1.4", Labels: map[string]string{"tier": "backend", composeProjectLabel: "shop", composeServiceLabel: "api"}},
+		{Name: "shop-api-1", Image: "ghcr.io/acme/api:1.4", Labels: map[string]string{"tier": "backend", composeProjectLabel: "shop", composeServiceLabel: "api"}},
+		{Name: "shop-db-1", Image: "postgres:16", Labels: map[string]string{"tier": "data", composeProjectLabel: "shop", composeServiceLabel: "db"}},
+		{Name: "other-api-1", Image: "ghcr.io/acme/api:1.3", Labels: map[string]string{"tier": "backend", composeProjectLabel: "other", composeServiceLabel: "api"}},
+	}
+
+	tests := []struct {
+		name     string
+		selector domainconfig.TargetSelector
+		want     []string
+	}{
+		{"label", domainconfig.TargetSelector{Labels: map[string]string{"tier": "backend"}}, []string{"other-api-1", "shop-api-1", "shop-api-2"}},
+		{"label presence", domainconfig.TargetSelector{Labels: map[string]string{"tier": ""}}, []string{"other-api-1", "shop-api-1", "shop-api-2", "shop-db-1"}},
+		{"compose service", domainconfig.TargetSelector{Project: "shop", Service: "api"}, []string{"shop-api-1", "shop-api-2"}},
+		{"image glob", domainconfig.TargetSelector{Image: "*/api:1.3"}, []string{"other-api-1"}},
+		{"image glob anchored", domainconfig.TargetSelector{Image: "postgres"}, nil},
+		{"name regex", domainconfig.TargetSelector{Name: `^shop-(db|api)-1$`}, []string{"shop-api-1", "shop-db-1"}},
+		{"combined", domainconfig.TargetSelector{Service: "api", Name: "-2$"}, []string{"shop-api-2"}},
+	}
+
+	for _, tt := range tests {
+		selector, err := Compile(tt.selector)
+		if err != nil {
+			t.Fatalf("%s: Compile returned error: %v", tt.name, err)
+		}
+		var got []string
+		for _, c := range selector.Filter(containers) {
+			got = append(got, c.Name)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+			}
+		}
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	if _, err := Compile(domainconfig.TargetSelector{}); !errors.Is(err, ErrEmptySelector) {
+		t.Fatalf("expected ErrEmptySelector, got %v", err)
+	}
+	if _, err := Compile(domainconfig.TargetSelector{Name: "("}); err == nil {
+		t.Fatalf("expected an invalid name regex to be rejected")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	got := Describe(domainconfig.TargetSelector{Labels: map[string]string{"tier": "backend", "canary": ""}, Service: "api", Image: "acme/*"})
+	want := "label canary label tier=backend service=api image=acme/*"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
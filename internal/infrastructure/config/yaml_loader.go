@@ -7,10 +7,23 @@ import (
 	"time"
 
 	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	domaintarget "github.com/lekhanpro/chaos-dock/internal/domain/target"
 	"gopkg.in/yaml.v3"
 )
 
+// LoadChaosConfig reads and validates a config that uses the built-in fault types.
 func LoadChaosConfig(path string) (domainconfig.ChaosConfig, error) {
+	faults, err := domainfault.NewBuiltinRegistry(domainfault.Injectors{})
+	if err != nil {
+		return domainconfig.ChaosConfig{}, err
+	}
+	return LoadChaosConfigWithFaults(path, faults)
+}
+
+// LoadChaosConfigWithFaults reads a config and validates every fault with the plugin faults
+// registers for its type.
+func LoadChaosConfigWithFaults(path string, faults *domainfault.Registry) (domainconfig.ChaosConfig, error) {
 	raw, err := os.ReadFile(path)
 	if err != nil {
 		return domainconfig.ChaosConfig{}, fmt.Errorf("read config %q: %w", path, err)
@@ -21,14 +34,14 @@ func LoadChaosConfig(path string) (domainconfig.ChaosConfig, error) {
 		return domainconfig.ChaosConfig{}, fmt.Errorf("parse yaml %q: %w", path, err)
 	}
 
-	if err := validate(cfg); err != nil {
+	if err := validate(cfg, faults); err != nil {
 		return domainconfig.ChaosConfig{}, err
 	}
 
 	return cfg, nil
 }
 
-func validate(cfg domainconfig.ChaosConfig) error {
+func validate(cfg domainconfig.ChaosConfig, faults *domainfault.Registry) error {
 	if len(cfg.Experiments) == 0 {
 		return fmt.Errorf("config requires at least one experiment")
 	}
@@ -37,8 +50,16 @@ func validate(cfg domainconfig.ChaosConfig) error {
 		if strings.TrimSpace(exp.Name) == "" {
 			return fmt.Errorf("experiments[%d].name is required", i)
 		}
-		if strings.TrimSpace(exp.TargetContainer) == "" {
-			return fmt.Errorf("experiments[%d].targetContainer is required", i)
+		if exp.Target == nil && strings.TrimSpace(exp.TargetContainer) == "" {
+			return fmt.Errorf("experiments[%d].targetContainer or target is required", i)
+		}
+		if exp.Target != nil {
+			if strings.TrimSpace(exp.TargetContainer) != "" {
+				return fmt.Errorf("experiments[%d].targetContainer and target are mutually exclusive", i)
+			}
+			if _, err := domaintarget.Compile(*exp.Target); err != nil {
+				return fmt.Errorf("experiments[%d].%w", i, err)
+			}
 		}
 		if strings.TrimSpace(exp.Fault.Type) == "" {
 			return fmt.Errorf("experiments[%d].fault.type is required", i)
@@ -55,39 +76,14 @@ func validate(cfg domainconfig.ChaosConfig) error {
 			}
 		}
 
-		switch exp.Fault.Type {
-		case "network-latency":
-			if strings.TrimSpace(exp.Fault.Delay) == "" {
-				return fmt.Errorf("experiments[%d].fault.delay is required for network-latency", i)
-			}
-			if _, err := time.ParseDuration(exp.Fault.Delay); err != nil {
-				return fmt.Errorf("experiments[%d].fault.delay must be a valid duration: %w", i, err)
-			}
-		case "kill":
-			if exp.Fault.Signal != "" && !isSupportedSignal(exp.Fault.Signal) {
-				return fmt.Errorf("experiments[%d].fault.signal %q is not supported", i, exp.Fault.Signal)
-			}
-		default:
+		plugin, err := faults.Lookup(exp.Fault.Type)
+		if err != nil {
 			return fmt.Errorf("experiments[%d].fault.type %q is unsupported", i, exp.Fault.Type)
 		}
+		if err := plugin.Validate(exp); err != nil {
+			return fmt.Errorf("experiments[%d].%w", i, err)
+		}
 	}
 
 	return nil
 }
-
-func isSupportedSignal(raw string) bool {
-	signal := strings.ToUpper(strings.TrimSpace(raw))
-	if signal == "" {
-		return true
-	}
-	if !strings.HasPrefix(signal, "SIG") {
-		signal = "SIG" + signal
-	}
-
-	switch signal {
-	case "SIGKILL", "SIGTERM", "SIGINT", "SIGQUIT", "SIGHUP", "SIGUSR1", "SIGUSR2":
-		return true
-	default:
-		return false
-	}
-}
@@ -68,3 +68,735 @@ experiments:
 		t.Fatalf("expected fault.signal validation error, got %v", err)
 	}
 }
+
+func TestLoadChaosConfig_NetworkLoss(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: lossy-db
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: network-loss
+      loss: 10%
+      correlation: 25%
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cfg, err := LoadChaosConfig(path)
+	if err != nil {
+		t.Fatalf("LoadChaosConfig returned error: %v", err)
+	}
+	if cfg.Experiments[0].Fault.Loss != "10%" {
+		t.Fatalf("unexpected loss %q", cfg.Experiments[0].Fault.Loss)
+	}
+}
+
+func TestLoadChaosConfig_InvalidLoss(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: lossy-db
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: network-loss
+      loss: 150%
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.loss") {
+		t.Fatalf("expected fault.loss validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_LatencyDistribution(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: wan-db
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: network-latency
+      delay: 100ms
+      jitter: 20ms
+      correlation: 25%
+      distribution: paretonormal
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if _, err := LoadChaosConfig(path); err != nil {
+		t.Fatalf("LoadChaosConfig returned error: %v", err)
+	}
+}
+
+func TestLoadChaosConfig_DistributionRequiresJitter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: wan-db
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: network-latency
+      delay: 100ms
+      distribution: normal
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.distribution") {
+		t.Fatalf("expected fault.distribution validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_BandwidthRequiresRate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: slow-uplink
+    targetContainer: api
+    enabled: true
+    fault:
+      type: bandwidth
+      burst: 32kb
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.rate") {
+		t.Fatalf("expected fault.rate validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_ReorderRequiresDelay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: reorder-api
+    targetContainer: api
+    enabled: true
+    fault:
+      type: network-reorder
+      reorder: 25%
+    schedule:
+      every: 60s
+  - name: corrupt-api
+    targetContainer: api
+    enabled: true
+    fault:
+      type: network-corrupt
+      corrupt: 1%
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "experiments[0].fault.delay") {
+		t.Fatalf("expected fault.delay validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_NetworkImpairmentBlock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: bad-wan
+    targetContainer: api
+    enabled: true
+    fault:
+      type: network
+      network:
+        delay: 100ms
+        jitter: 20ms
+        loss: 5%
+        reorder: 10%
+        rate: 1mbit
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cfg, err := LoadChaosConfig(path)
+	if err != nil {
+		t.Fatalf("LoadChaosConfig returned error: %v", err)
+	}
+	if cfg.Experiments[0].Fault.Network == nil || cfg.Experiments[0].Fault.Network.Rate != "1mbit" {
+		t.Fatalf("expected network block to be parsed, got %#v", cfg.Experiments[0].Fault.Network)
+	}
+}
+
+func TestLoadChaosConfig_NetworkImpairmentReorderRequiresDelay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: bad-wan
+    targetContainer: api
+    enabled: true
+    fault:
+      type: network
+      network:
+        reorder: 10%
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.network.reorder requires delay") {
+		t.Fatalf("expected fault.network.reorder validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_PartitionPeerMustDifferFromTarget(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: split-api-db
+    targetContainer: api
+    enabled: true
+    fault:
+      type: network-partition
+      peers: [postgres, api]
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.peers[1]") {
+		t.Fatalf("expected fault.peers[1] validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_PortBlackholeInvalidAction(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: redis-refused
+    targetContainer: api
+    enabled: true
+    fault:
+      type: port-blackhole
+      ports: [6379]
+      protocols: [tcp]
+      action: explode
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.action") {
+		t.Fatalf("expected fault.action validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_DNSDelayRequiresDelay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: slow-dns
+    targetContainer: api
+    enabled: true
+    fault:
+      type: dns
+      mode: delay
+      duration: 30s
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.delay") {
+		t.Fatalf("expected fault.delay validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_DNSRequiresDuration(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: postgres-unresolvable
+    targetContainer: api
+    enabled: true
+    fault:
+      type: dns
+      mode: nxdomain
+      domains: [postgres]
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.duration") {
+		t.Fatalf("expected fault.duration validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_ShapingInvalidDirection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: slow-receive
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: network-latency
+      delay: 200ms
+      direction: inbound
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.direction") {
+		t.Fatalf("expected fault.direction validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_DestinationRequiresEgress(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: api-slow-db
+    targetContainer: api
+    enabled: true
+    fault:
+      type: network-loss
+      loss: 5%
+      to: [postgres]
+      direction: ingress
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "require direction egress") {
+		t.Fatalf("expected destination direction validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_CPUStressInvalidLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: api-starved
+    targetContainer: api
+    enabled: true
+    fault:
+      type: cpu-stress
+      workers: 2
+      load: 150%
+      duration: 30s
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.load") {
+		t.Fatalf("expected fault.load validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_MemoryStressRequiresSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: api-memory-pressure
+    targetContainer: api
+    enabled: true
+    fault:
+      type: memory-stress
+      duration: 30s
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.size") {
+		t.Fatalf("expected fault.size validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_IOThrottleRequiresLimit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: postgres-slow-disk
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: io-throttle
+      device: /dev/sda
+      duration: 1m
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.readBps") {
+		t.Fatalf("expected io limit validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_DiskFillRejectsPathAndVolume(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: postgres-full-disk
+    targetContainer: postgres
+    enabled: true
+    fault:
+      type: disk-fill
+      path: /var/lib/postgresql/data
+      volume: pgdata
+      size: 1gb
+      duration: 30s
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.path or fault.volume") {
+		t.Fatalf("expected path/volume validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_RestartLoopRequiresInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: flapping-api
+    targetContainer: api
+    enabled: true
+    fault:
+      type: restart-loop
+      count: 3
+    schedule:
+      every: 5m
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.interval") {
+		t.Fatalf("expected fault.interval validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_ProcessKillRejectsInvalidPattern(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: kill-celery-worker
+    targetContainer: worker
+    enabled: true
+    fault:
+      type: process-kill
+      cmdline: "celery [worker"
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.cmdline") {
+		t.Fatalf("expected fault.cmdline validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_ClockSkewRequiresOffset(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: expire-tokens
+    targetContainer: auth
+    enabled: true
+    fault:
+      type: clock-skew
+      offset: 0s
+      duration: 5m
+    schedule:
+      every: 10m
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.offset") {
+		t.Fatalf("expected fault.offset validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_HTTPRequiresAbortOrDelay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: orders-unavailable
+    targetContainer: api
+    enabled: true
+    fault:
+      type: http
+      ports: [8080]
+      routes:
+        - method: POST
+          path: /orders
+      percentage: 10%
+      duration: 5m
+    schedule:
+      every: 10m
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.abort or fault.delay") {
+		t.Fatalf("expected abort/delay validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_TCPResetRejectsInvalidRate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: db-pool-recovery
+    targetContainer: api
+    enabled: true
+    fault:
+      type: tcp-reset
+      peers: [postgres]
+      rate: 5kbit
+      duration: 30s
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.rate") {
+		t.Fatalf("expected fault.rate validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_ResourceExhaustionRequiresCountOrPercentage(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: fd-exhaustion
+    targetContainer: api
+    enabled: true
+    fault:
+      type: resource-exhaustion
+      resource: files
+      duration: 1m
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "fault.count or fault.percentage") {
+		t.Fatalf("expected fault.count or fault.percentage validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_TargetSelector(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: backend-latency
+    target:
+      labels:
+        tier: backend
+      project: shop
+      image: ghcr.io/acme/*
+    enabled: true
+    fault:
+      type: network-latency
+      delay: 200ms
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cfg, err := LoadChaosConfig(path)
+	if err != nil {
+		t.Fatalf("LoadChaosConfig returned error: %v", err)
+	}
+	target := cfg.Experiments[0].Target
+	if target == nil || target.Labels["tier"] != "backend" || target.Project != "shop" || target.Image != "ghcr.io/acme/*" {
+		t.Fatalf("unexpected target selector %#v", target)
+	}
+}
+
+func TestLoadChaosConfig_TargetSelectorRejectsTargetContainer(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: backend-latency
+    targetContainer: api
+    target:
+      service: api
+    enabled: true
+    fault:
+      type: network-latency
+      delay: 200ms
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive validation error, got %v", err)
+	}
+}
+
+func TestLoadChaosConfig_TargetSelectorRejectsInvalidName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chaos.yaml")
+
+	content := `
+experiments:
+  - name: backend-latency
+    target:
+      name: "shop-(api"
+    enabled: true
+    fault:
+      type: network-latency
+      delay: 200ms
+    schedule:
+      every: 60s
+`
+	if err := os.WriteFile(path, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	_, err := LoadChaosConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "target.name") {
+		t.Fatalf("expected target.name validation error, got %v", err)
+	}
+}
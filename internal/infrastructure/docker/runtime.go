@@ -3,11 +3,16 @@ package docker
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	apicontainer "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+
+	"github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	"github.com/lekhanpro/chaos-dock/internal/domain/target"
 )
 
 type Runtime struct {
@@ -19,6 +24,7 @@ type ContainerSummary struct {
 	Name   string
 	Image  string
 	Status string
+	Labels map[string]string
 }
 
 func NewRuntimeFromEnv() (*Runtime, error) {
@@ -58,6 +64,47 @@ func (r *Runtime) ContainerPID(ctx context.Context, containerID string) (int, er
 	return inspect.State.Pid, nil
 }
 
+// ContainerIPs returns the IPv4 and IPv6 addresses of a container across all of its networks.
+func (r *Runtime) ContainerIPs(ctx context.Context, containerID string) ([]string, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return nil, fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return nil, fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+	if inspect.NetworkSettings == nil {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(inspect.NetworkSettings.Networks))
+	for name := range inspect.NetworkSettings.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ips []string
+	for _, name := range names {
+		endpoint := inspect.NetworkSettings.Networks[name]
+		if endpoint == nil {
+			continue
+		}
+		if endpoint.IPAddress != "" {
+			ips = append(ips, endpoint.IPAddress)
+		}
+		if endpoint.GlobalIPv6Address != "" {
+			ips = append(ips, endpoint.GlobalIPv6Address)
+		}
+	}
+
+	return ips, nil
+}
+
 func (r *Runtime) ListRunningContainers(ctx context.Context) ([]ContainerSummary, error) {
 	if r == nil || r.client == nil {
 		return nil, fmt.Errorf("docker runtime client is not initialized")
@@ -92,12 +139,242 @@ func (r *Runtime) ListRunningContainers(ctx context.Context) ([]ContainerSummary
 			Name:   name,
 			Image:  c.Image,
 			Status: c.Status,
+			Labels: c.Labels,
 		})
 	}
 
 	return out, nil
 }
 
+// RunningContainers lists running containers for experiment target selectors.
+func (r *Runtime) RunningContainers(ctx context.Context) ([]target.Container, error) {
+	summaries, err := r.ListRunningContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]target.Container, 0, len(summaries))
+	for _, c := range summaries {
+		out = append(out, target.Container{ID: c.ID, Name: c.Name, Image: c.Image, Labels: c.Labels})
+	}
+	return out, nil
+}
+
+// OOMKilled reports whether Docker recorded an OOM kill for the container.
+func (r *Runtime) OOMKilled(ctx context.Context, containerID string) (bool, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return false, fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return false, fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return false, fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+	if inspect.State == nil {
+		return false, nil
+	}
+
+	return inspect.State.OOMKilled, nil
+}
+
+// ContainerRunning reports whether the container is running. A paused container counts as
+// running.
+func (r *Runtime) ContainerRunning(ctx context.Context, containerID string) (bool, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return false, fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return false, fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return false, fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+	if inspect.State == nil {
+		return false, nil
+	}
+
+	return inspect.State.Running, nil
+}
+
+// ConfiguredIOLimits returns the per-device blkio throttles the container was created with,
+// which is what its I/O limits are restored to.
+func (r *Runtime) ConfiguredIOLimits(ctx context.Context, containerID string) ([]fault.IOThrottle, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return nil, fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return nil, fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+	if inspect.HostConfig == nil {
+		return nil, nil
+	}
+
+	byDevice := make(map[string]*fault.IOThrottle)
+	var devices []string
+	limit := func(path string) *fault.IOThrottle {
+		if l, ok := byDevice[path]; ok {
+			return l
+		}
+		l := &fault.IOThrottle{Device: path}
+		byDevice[path] = l
+		devices = append(devices, path)
+		return l
+	}
+
+	res := inspect.HostConfig.Resources
+	for _, d := range res.BlkioDeviceReadBps {
+		limit(d.Path).ReadBPS = d.Rate
+	}
+	for _, d := range res.BlkioDeviceWriteBps {
+		limit(d.Path).WriteBPS = d.Rate
+	}
+	for _, d := range res.BlkioDeviceReadIOps {
+		limit(d.Path).ReadIOPS = d.Rate
+	}
+	for _, d := range res.BlkioDeviceWriteIOps {
+		limit(d.Path).WriteIOPS = d.Rate
+	}
+
+	out := make([]fault.IOThrottle, 0, len(devices))
+	for _, path := range devices {
+		out = append(out, *byDevice[path])
+	}
+	return out, nil
+}
+
+// VolumeDestination returns the mount point of a volume inside the container. Compose
+// project prefixes are accepted, so "pgdata" also matches "shop_pgdata".
+func (r *Runtime) VolumeDestination(ctx context.Context, containerID string, volume string) (string, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return "", fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return "", fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+
+	volume = strings.TrimSpace(volume)
+	for _, m := range inspect.Mounts {
+		if m.Name == volume || strings.HasSuffix(m.Name, "_"+volume) {
+			return m.Destination, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q in %q", fault.ErrVolumeNotMounted, volume, containerID)
+}
+
+// NetworkEndpoint returns the container's attachment to a network. Compose project prefixes
+// are accepted, so "backend" also matches "shop_backend".
+func (r *Runtime) NetworkEndpoint(ctx context.Context, containerID string, networkName string) (fault.NetworkEndpoint, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fault.NetworkEndpoint{}, fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fault.NetworkEndpoint{}, fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fault.NetworkEndpoint{}, fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+
+	networkName = strings.TrimSpace(networkName)
+	if inspect.NetworkSettings != nil {
+		for name, endpoint := range inspect.NetworkSettings.Networks {
+			if endpoint == nil || (name != networkName && !strings.HasSuffix(name, "_"+networkName)) {
+				continue
+			}
+
+			out := fault.NetworkEndpoint{
+				Network:     name,
+				Links:       endpoint.Links,
+				DriverOpts:  endpoint.DriverOpts,
+				IPv4Address: endpoint.IPAddress,
+				IPv6Address: endpoint.GlobalIPv6Address,
+			}
+			// Older engines list the short container ID as an alias; it is added back on connect.
+			for _, alias := range endpoint.Aliases {
+				if !strings.HasPrefix(inspect.ID, alias) {
+					out.Aliases = append(out.Aliases, alias)
+				}
+			}
+			if ipam := endpoint.IPAMConfig; ipam != nil && (ipam.IPv4Address != "" || ipam.IPv6Address != "") {
+				out.IPv4Address = ipam.IPv4Address
+				out.IPv6Address = ipam.IPv6Address
+				out.StaticIP = true
+			}
+			return out, nil
+		}
+	}
+
+	return fault.NetworkEndpoint{}, fmt.Errorf("%w: %q in %q", fault.ErrNetworkNotAttached, networkName, containerID)
+}
+
+func (r *Runtime) DisconnectNetwork(ctx context.Context, containerID string, networkName string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	if err := r.client.NetworkDisconnect(ctx, networkName, containerID, false); err != nil {
+		return fmt.Errorf("disconnect container %q from network %q: %w", containerID, networkName, err)
+	}
+
+	return nil
+}
+
+// ConnectNetwork attaches the container to endpoint.Network, requesting the endpoint's
+// addresses when they are set.
+func (r *Runtime) ConnectNetwork(ctx context.Context, containerID string, endpoint fault.NetworkEndpoint) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	settings := &network.EndpointSettings{
+		Aliases:    endpoint.Aliases,
+		Links:      endpoint.Links,
+		DriverOpts: endpoint.DriverOpts,
+	}
+	if endpoint.IPv4Address != "" || endpoint.IPv6Address != "" {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{
+			IPv4Address: endpoint.IPv4Address,
+			IPv6Address: endpoint.IPv6Address,
+		}
+	}
+
+	if err := r.client.NetworkConnect(ctx, endpoint.Network, containerID, settings); err != nil {
+		return fmt.Errorf("connect container %q to network %q: %w", containerID, endpoint.Network, err)
+	}
+
+	return nil
+}
+
 func (r *Runtime) Restart(ctx context.Context, containerID string) error {
 	containerID = strings.TrimSpace(containerID)
 	if containerID == "" {
@@ -114,6 +391,81 @@ func (r *Runtime) Restart(ctx context.Context, containerID string) error {
 	return nil
 }
 
+// Pause freezes every process of the container through the cgroup freezer.
+func (r *Runtime) Pause(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	if err := r.client.ContainerPause(ctx, containerID); err != nil {
+		return fmt.Errorf("pause container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+// Unpause thaws a paused container. Containers that are not paused are left alone.
+func (r *Runtime) Unpause(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	inspect, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("inspect container %q: %w", containerID, err)
+	}
+	if inspect.State == nil || !inspect.State.Paused {
+		return nil
+	}
+
+	if err := r.client.ContainerUnpause(ctx, containerID); err != nil {
+		return fmt.Errorf("unpause container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+// Stop stops the container gracefully, sending SIGKILL after Docker's stop timeout.
+func (r *Runtime) Stop(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	if err := r.client.ContainerStop(ctx, containerID, apicontainer.StopOptions{}); err != nil {
+		return fmt.Errorf("stop container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+func (r *Runtime) Start(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container id is required")
+	}
+	if r == nil || r.client == nil {
+		return fmt.Errorf("docker runtime client is not initialized")
+	}
+
+	if err := r.client.ContainerStart(ctx, containerID, apicontainer.StartOptions{}); err != nil {
+		return fmt.Errorf("start container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
 func (r *Runtime) Kill(ctx context.Context, containerID string, signal string) error {
 	containerID = strings.TrimSpace(containerID)
 	if containerID == "" {
@@ -0,0 +1,94 @@
+package fault
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupDir locates the directory of a process cgroup from the contents of /proc/<pid>/cgroup.
+// A cgroup v1 hierarchy that carries controller wins; otherwise the unified v2 hierarchy is used.
+func cgroupDir(procCgroup string, controller string) (string, error) {
+	var unified string
+	for _, line := range strings.Split(procCgroup, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			unified = parts[2]
+			continue
+		}
+		for _, name := range strings.Split(parts[1], ",") {
+			if name == controller {
+				return filepath.Join(cgroupRoot, parts[1], parts[2]), nil
+			}
+		}
+	}
+
+	if unified == "" {
+		return "", fmt.Errorf("%w: no %s or unified hierarchy", domainfault.ErrCgroupUnavailable, controller)
+	}
+	return filepath.Join(cgroupRoot, unified), nil
+}
+
+// unlimitedMemory is the smallest value cgroup v1 reports for an unlimited memory.limit_in_bytes
+// (the page-aligned maximum of int64).
+const unlimitedMemory = 1 << 62
+
+// parseMemoryLimit parses memory.max (cgroup v2) or memory.limit_in_bytes (cgroup v1).
+// It reports false when the cgroup has no limit.
+func parseMemoryLimit(raw string) (uint64, bool) {
+	value := strings.TrimSpace(raw)
+	if value == "max" {
+		return 0, false
+	}
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || limit == 0 || limit >= unlimitedMemory {
+		return 0, false
+	}
+	return limit, true
+}
+
+// swapAllowance returns how much swap a cgroup with memory limit may use. raw is the content
+// of memory.swap.max (cgroup v2, swap alone) or, with combined set, memory.memsw.limit_in_bytes
+// (cgroup v1, memory plus swap). An unlimited value leaves the host's swap as the bound.
+func swapAllowance(raw string, combined bool, limit uint64, hostSwap uint64) uint64 {
+	value, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+	if err != nil || value >= unlimitedMemory {
+		// "max", or the page-aligned maximum cgroup v1 reports.
+		return hostSwap
+	}
+	if combined {
+		if value <= limit {
+			return 0
+		}
+		value -= limit
+	}
+	return min(value, hostSwap)
+}
+
+// parseSwapTotal returns SwapTotal from /proc/meminfo in bytes.
+func parseSwapTotal(meminfo string) uint64 {
+	for _, line := range strings.Split(meminfo, "\n") {
+		rest, ok := strings.CutPrefix(line, "SwapTotal:")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return 0
+		}
+		kib, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kib * 1024
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// containerCgroupDir returns the cgroup directory of the container process pid for controller.
+func containerCgroupDir(pid int, controller string) (string, error) {
+	raw, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+	return cgroupDir(string(raw), controller)
+}
+
+// memoryLimit returns the memory limit of the cgroup at dir.
+func memoryLimit(dir string) (uint64, error) {
+	for _, name := range []string{"memory.max", "memory.limit_in_bytes"} {
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+		}
+		if limit, ok := parseMemoryLimit(string(raw)); ok {
+			return limit, nil
+		}
+		return 0, domainfault.ErrMemoryLimitUnavailable
+	}
+	return 0, fmt.Errorf("%w: no memory controller in %s", domainfault.ErrCgroupUnavailable, dir)
+}
+
+// swapLimit returns how much swap the cgroup at dir may use on top of its memory limit. A
+// cgroup without swap accounting is bounded by the host's swap only.
+func swapLimit(dir string, limit uint64) (uint64, error) {
+	meminfo, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, fmt.Errorf("read host swap: %w", err)
+	}
+	hostSwap := parseSwapTotal(string(meminfo))
+
+	for _, file := range []struct {
+		name     string
+		combined bool
+	}{{"memory.swap.max", false}, {"memory.memsw.limit_in_bytes", true}} {
+		raw, err := os.ReadFile(filepath.Join(dir, file.name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+		}
+		return swapAllowance(string(raw), file.combined, limit, hostSwap), nil
+	}
+	return hostSwap, nil
+}
+
+// startHelper starts the chaos-dock binary as helper inside the cgroup at dir, so none of
+// its work is charged to chaos-dock. On cgroup v2 the helper is created in the cgroup with
+// clone3; on cgroup v1, or kernels without clone3, it joins the cgroup itself before doing
+// any work and exits if it cannot.
+func startHelper(dir string, args []string) (*exec.Cmd, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("%w: locate executable: %v", domainfault.ErrStressHelperFailed, err)
+	}
+
+	cgroup, err := os.Open(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("%w: %v", domainfault.ErrInsufficientPrivileges, err)
+		}
+		return nil, fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+	defer cgroup.Close()
+
+	cmd := helperCmd(self, dir, args)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, UseCgroupFD: true, CgroupFD: int(cgroup.Fd())}
+	err = cmd.Start()
+	if errors.Is(err, syscall.ENOSYS) || errors.Is(err, syscall.EBADF) {
+		// No clone3, or dir is on a cgroup v1 hierarchy.
+		cmd = helperCmd(self, dir, args)
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		err = cmd.Start()
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("%w: %v", domainfault.ErrInsufficientPrivileges, err)
+		}
+		return nil, fmt.Errorf("%w: %v", domainfault.ErrStressHelperFailed, err)
+	}
+	return cmd, nil
+}
+
+func helperCmd(self string, dir string, args []string) *exec.Cmd {
+	cmd := exec.Command(self, args...)
+	cmd.Env = append(os.Environ(), helperCgroupEnv+"="+dir)
+	return cmd
+}
+
+// waitHelper waits for cmd to finish or ctx to end, in which case the helper is killed.
+// A helper killed from outside, for example by a revert, is not an error.
+func waitHelper(ctx context.Context, cmd *exec.Cmd) error {
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		<-done
+		return nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", domainfault.ErrStressHelperFailed, err)
+	}
+	return nil
+}
+
+// killHelpers kills every helper of kind in the cgroup at dir. It works across chaos-dock
+// processes, so a panic rollback can stop helpers started by an earlier run.
+func killHelpers(dir string, kind string) error {
+	raw, err := os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+
+	var errs []error
+	for _, field := range strings.Fields(string(raw)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join("/proc", field, "cmdline"))
+		if err != nil || !isHelperCmdline(cmdline, kind) {
+			continue
+		}
+		if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+			errs = append(errs, fmt.Errorf("kill helper %d: %w", pid, err))
+		}
+	}
+	return errors.Join(errs...)
+}
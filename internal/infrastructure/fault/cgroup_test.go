@@ -0,0 +1,76 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestCgroupDir_Unified(t *testing.T) {
+	dir, err := cgroupDir("0::/system.slice/docker-abc.scope\n", "cpu")
+	if err != nil {
+		t.Fatalf("cgroupDir returned error: %v", err)
+	}
+	if dir != "/sys/fs/cgroup/system.slice/docker-abc.scope" {
+		t.Fatalf("unexpected dir %q", dir)
+	}
+}
+
+func TestCgroupDir_V1Controller(t *testing.T) {
+	content := "12:memory:/docker/abc\n4:cpu,cpuacct:/docker/abc\n0::/\n"
+
+	dir, err := cgroupDir(content, "cpu")
+	if err != nil {
+		t.Fatalf("cgroupDir returned error: %v", err)
+	}
+	if dir != "/sys/fs/cgroup/cpu,cpuacct/docker/abc" {
+		t.Fatalf("unexpected dir %q", dir)
+	}
+}
+
+func TestCgroupDir_Missing(t *testing.T) {
+	if _, err := cgroupDir("12:memory:/docker/abc\n", "cpu"); !errors.Is(err, domainfault.ErrCgroupUnavailable) {
+		t.Fatalf("expected ErrCgroupUnavailable, got %v", err)
+	}
+}
+
+func TestParseMemoryLimit(t *testing.T) {
+	if limit, ok := parseMemoryLimit("536870912\n"); !ok || limit != 512*1024*1024 {
+		t.Fatalf("parseMemoryLimit(512MiB) = %d, %v", limit, ok)
+	}
+	for _, raw := range []string{"max\n", "9223372036854771712\n", ""} {
+		if _, ok := parseMemoryLimit(raw); ok {
+			t.Fatalf("parseMemoryLimit(%q) should report no limit", raw)
+		}
+	}
+}
+
+func TestSwapAllowance(t *testing.T) {
+	const limit = 512 * 1024 * 1024
+	const hostSwap = 2 * 1024 * 1024 * 1024
+
+	cases := []struct {
+		name     string
+		raw      string
+		combined bool
+		want     uint64
+	}{
+		{"v2 limited", "536870912\n", false, limit},
+		{"v2 disabled", "0\n", false, 0},
+		{"v2 unlimited", "max\n", false, hostSwap},
+		{"v1 memory plus swap", "1073741824\n", true, limit},
+		{"v1 no swap", "536870912\n", true, 0},
+		{"v1 unlimited", "9223372036854771712\n", true, hostSwap},
+	}
+	for _, tc := range cases {
+		if got := swapAllowance(tc.raw, tc.combined, limit, hostSwap); got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+
+	meminfo := "MemTotal:       16314844 kB\nSwapTotal:       2097148 kB\nSwapFree:        2097148 kB\n"
+	if got := parseSwapTotal(meminfo); got != 2097148*1024 {
+		t.Fatalf("unexpected SwapTotal %d", got)
+	}
+}
@@ -0,0 +1,86 @@
+package fault
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// faketimeConfig is the file libfaketime reads its offset from when neither FAKETIME nor
+// FAKETIME_TIMESTAMP_FILE is set and the user has no ~/.faketimerc.
+const faketimeConfig = "/etc/faketimerc"
+
+func validateClockSkew(skew domainfault.ClockSkew) error {
+	if skew.Offset == 0 {
+		return fmt.Errorf("%w: offset must not be zero", domainfault.ErrInvalidClockSkew)
+	}
+	if skew.Offset%time.Second != 0 {
+		return fmt.Errorf("%w: offset must be a whole number of seconds", domainfault.ErrInvalidClockSkew)
+	}
+	if skew.Duration <= 0 {
+		return fmt.Errorf("%w: duration must be greater than zero", domainfault.ErrInvalidClockSkew)
+	}
+	return nil
+}
+
+// faketimeOffset renders offset as a libfaketime relative offset in seconds, e.g. "-7200".
+func faketimeOffset(offset time.Duration) string {
+	seconds := int64(offset / time.Second)
+	if seconds < 0 {
+		return strconv.FormatInt(seconds, 10) + "\n"
+	}
+	return "+" + strconv.FormatInt(seconds, 10) + "\n"
+}
+
+// faketimeLoaded reports whether a /proc/<pid>/maps listing includes libfaketime.
+func faketimeLoaded(maps string) bool {
+	for _, line := range strings.Split(maps, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 6 && strings.Contains(path.Base(fields[len(fields)-1]), "libfaketime") {
+			return true
+		}
+	}
+	return false
+}
+
+// faketimeFiles returns, from a /proc/<pid>/environ listing, the files libfaketime reads its
+// offset from in order of precedence. A FAKETIME variable overrides every file, so it is
+// reported as an error.
+func faketimeFiles(environ []byte) ([]string, error) {
+	env := map[string]string{}
+	for _, entry := range bytes.Split(environ, []byte{0}) {
+		name, value, ok := strings.Cut(string(entry), "=")
+		if ok {
+			env[name] = value
+		}
+	}
+	if value, ok := env["FAKETIME"]; ok {
+		return nil, fmt.Errorf("%w: FAKETIME=%q in the environment takes precedence over the offset file", domainfault.ErrClockSkewUnsupported, value)
+	}
+	if file := strings.TrimSpace(env["FAKETIME_TIMESTAMP_FILE"]); file != "" {
+		return []string{path.Clean("/" + file)}, nil
+	}
+	files := []string{}
+	if home := strings.TrimSpace(env["HOME"]); home != "" {
+		files = append(files, path.Join("/", home, ".faketimerc"))
+	}
+	return append(files, faketimeConfig), nil
+}
+
+// clockSkewRecord is what a clock skew replaced: the offset file inside the container and
+// its previous content, if it existed.
+type clockSkewRecord struct {
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+	Content string `json:"content,omitempty"`
+}
+
+// newClockSkewLedger records, per container, the offset files a clock skew replaced.
+func newClockSkewLedger() ledger[clockSkewRecord] {
+	return newLedger("clock-skew", func(record clockSkewRecord) string { return record.Path })
+}
@@ -0,0 +1,220 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ClockSkewInjector shifts a container's clocks by writing a relative offset into the file
+// libfaketime reads inside the container. The kernel has no per-container CLOCK_REALTIME, so
+// the target must already preload libfaketime (LD_PRELOAD); processes pick the offset up when
+// libfaketime next rereads the file, within 10 seconds by default.
+type ClockSkewInjector struct {
+	pidResolver PIDResolver
+	ledger      ledger[clockSkewRecord]
+}
+
+func NewClockSkewInjector(pidResolver PIDResolver) *ClockSkewInjector {
+	return &ClockSkewInjector{pidResolver: pidResolver, ledger: newClockSkewLedger()}
+}
+
+func (c *ClockSkewInjector) SkewClock(ctx context.Context, containerID string, skew domainfault.ClockSkew) (err error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if c.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+	if err := validateClockSkew(skew); err != nil {
+		return err
+	}
+
+	pid, err := c.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve container pid: %w", err)
+	}
+
+	file, err := faketimeFile(pid)
+	if err != nil {
+		return fmt.Errorf("container %q: %w", containerID, err)
+	}
+	record, err := readContainerFile(pid, file)
+	if err != nil {
+		return fmt.Errorf("read %s in container %q: %w", file, containerID, err)
+	}
+
+	if err := c.ledger.record(containerID, record); err != nil {
+		return err
+	}
+	defer func() {
+		if restoreErr := restoreContainerFile(pid, record); restoreErr != nil {
+			err = errors.Join(err, fmt.Errorf("restore %s in container %q: %w", file, containerID, restoreErr))
+			return
+		}
+		if forgetErr := c.ledger.forget(containerID, file); forgetErr != nil {
+			err = errors.Join(err, forgetErr)
+		}
+	}()
+
+	if err := writeContainerFile(pid, file, faketimeOffset(skew.Offset)); err != nil {
+		return fmt.Errorf("write %s in container %q: %w", file, containerID, err)
+	}
+
+	holdCtx, cancel := context.WithTimeout(ctx, skew.Duration)
+	defer cancel()
+	<-holdCtx.Done()
+	return nil
+}
+
+// RevertClockSkew restores every offset file recorded for the container, including those
+// left by a chaos-dock process that stopped before its fault ended.
+func (c *ClockSkewInjector) RevertClockSkew(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+
+	records, err := c.ledger.entries(containerID)
+	if err != nil || len(records) == 0 {
+		return err
+	}
+	if c.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := c.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	var errs []error
+	for _, record := range records {
+		if err := restoreContainerFile(pid, record); err != nil {
+			errs = append(errs, fmt.Errorf("restore %s in container %q: %w", record.Path, containerID, err))
+			continue
+		}
+		if err := c.ledger.forget(containerID, record.Path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// faketimeFile checks that the container's init process has libfaketime loaded and returns
+// the offset file it reads: the first existing candidate, or the last one.
+func faketimeFile(pid int) (string, error) {
+	proc := filepath.Join("/proc", strconv.Itoa(pid))
+	maps, err := os.ReadFile(filepath.Join(proc, "maps"))
+	if err != nil {
+		return "", fmt.Errorf("read memory map of process %d: %w", pid, err)
+	}
+	if !faketimeLoaded(string(maps)) {
+		return "", fmt.Errorf("%w: process %d does not preload libfaketime", domainfault.ErrClockSkewUnsupported, pid)
+	}
+
+	environ, err := os.ReadFile(filepath.Join(proc, "environ"))
+	if err != nil {
+		return "", fmt.Errorf("read environment of process %d: %w", pid, err)
+	}
+	files, err := faketimeFiles(environ)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files[:len(files)-1] {
+		record, err := readContainerFile(pid, file)
+		if err == nil && record.Existed {
+			return file, nil
+		}
+	}
+	return files[len(files)-1], nil
+}
+
+// readContainerFile captures file as seen from inside the container.
+func readContainerFile(pid int, file string) (clockSkewRecord, error) {
+	record := clockSkewRecord{Path: file}
+	dirFD, err := openContainerDir(pid, path.Dir(file))
+	if err != nil {
+		return record, err
+	}
+	defer unix.Close(dirFD)
+
+	fd, err := unix.Openat(dirFD, path.Base(file), unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) {
+		return record, nil
+	}
+	if err != nil {
+		return record, err
+	}
+	f := os.NewFile(uintptr(fd), file)
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return record, err
+	}
+	record.Existed = true
+	record.Content = string(content)
+	return record, nil
+}
+
+// writeContainerFile replaces file inside the container through a rename, so libfaketime
+// never reads a partly written offset.
+func writeContainerFile(pid int, file string, content string) error {
+	dirFD, err := openContainerDir(pid, path.Dir(file))
+	if err != nil {
+		return err
+	}
+	defer unix.Close(dirFD)
+
+	temp := "." + path.Base(file) + ".chaos-dock"
+	fd, err := unix.Openat(dirFD, temp, unix.O_WRONLY|unix.O_CREAT|unix.O_TRUNC|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0o644)
+	if err != nil {
+		return err
+	}
+	f := os.NewFile(uintptr(fd), temp)
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		unix.Unlinkat(dirFD, temp, 0)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		unix.Unlinkat(dirFD, temp, 0)
+		return err
+	}
+	if err := unix.Renameat(dirFD, temp, dirFD, path.Base(file)); err != nil {
+		unix.Unlinkat(dirFD, temp, 0)
+		return err
+	}
+	return nil
+}
+
+func restoreContainerFile(pid int, record clockSkewRecord) error {
+	if record.Existed {
+		return writeContainerFile(pid, record.Path, record.Content)
+	}
+	dirFD, err := openContainerDir(pid, path.Dir(record.Path))
+	if err != nil {
+		return err
+	}
+	defer unix.Close(dirFD)
+
+	err = unix.Unlinkat(dirFD, path.Base(record.Path), 0)
+	if errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type ClockSkewInjector struct{}
+
+func NewClockSkewInjector(_ PIDResolver) *ClockSkewInjector {
+	return &ClockSkewInjector{}
+}
+
+func (c *ClockSkewInjector) SkewClock(ctx context.Context, containerID string, skew domainfault.ClockSkew) error {
+	_ = ctx
+	_ = containerID
+	_ = skew
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (c *ClockSkewInjector) RevertClockSkew(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,68 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestValidateClockSkew(t *testing.T) {
+	if err := validateClockSkew(domainfault.ClockSkew{Offset: -2 * time.Hour, Duration: time.Minute}); err != nil {
+		t.Fatalf("expected negative offset to be valid, got %v", err)
+	}
+
+	for _, skew := range []domainfault.ClockSkew{
+		{Duration: time.Minute},
+		{Offset: time.Hour},
+		{Offset: 1500 * time.Millisecond, Duration: time.Minute},
+	} {
+		if err := validateClockSkew(skew); !errors.Is(err, domainfault.ErrInvalidClockSkew) {
+			t.Fatalf("expected ErrInvalidClockSkew for %#v, got %v", skew, err)
+		}
+	}
+}
+
+func TestFaketimeOffset(t *testing.T) {
+	for offset, want := range map[time.Duration]string{
+		-2 * time.Hour:   "-7200\n",
+		90 * time.Second: "+90\n",
+	} {
+		if got := faketimeOffset(offset); got != want {
+			t.Fatalf("expected %q for %s, got %q", want, offset, got)
+		}
+	}
+}
+
+func TestFaketimeLoaded(t *testing.T) {
+	maps := "7f0000000000-7f0000001000 r-xp 00000000 08:01 1234 /usr/lib/x86_64-linux-gnu/faketime/libfaketime.so.1\n"
+	if !faketimeLoaded(maps) {
+		t.Fatalf("expected libfaketime to be detected")
+	}
+	if faketimeLoaded("7f0000000000-7f0000001000 r-xp 00000000 08:01 1234 /usr/lib/libc.so.6\n") {
+		t.Fatalf("expected libc alone not to count as libfaketime")
+	}
+}
+
+func TestFaketimeFiles(t *testing.T) {
+	files, err := faketimeFiles([]byte("PATH=/usr/bin\x00HOME=/root\x00"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || files[0] != "/root/.faketimerc" || files[1] != "/etc/faketimerc" {
+		t.Fatalf("expected home then /etc offset files, got %v", files)
+	}
+
+	files, err = faketimeFiles([]byte("HOME=/root\x00FAKETIME_TIMESTAMP_FILE=/run/faketime\x00"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0] != "/run/faketime" {
+		t.Fatalf("expected FAKETIME_TIMESTAMP_FILE to win, got %v", files)
+	}
+
+	if _, err := faketimeFiles([]byte("FAKETIME=@2020-01-01 00:00:00\x00")); !errors.Is(err, domainfault.ErrClockSkewUnsupported) {
+		t.Fatalf("expected ErrClockSkewUnsupported when FAKETIME is set, got %v", err)
+	}
+}
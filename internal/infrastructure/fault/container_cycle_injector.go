@@ -0,0 +1,95 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type cycleExecutor interface {
+	Stop(ctx context.Context, containerID string) error
+	Start(ctx context.Context, containerID string) error
+	Restart(ctx context.Context, containerID string) error
+}
+
+// ContainerCycleInjector takes containers down through the Docker stop, start and restart APIs.
+type ContainerCycleInjector struct {
+	executor cycleExecutor
+}
+
+func NewContainerCycleInjector(executor cycleExecutor) *ContainerCycleInjector {
+	return &ContainerCycleInjector{executor: executor}
+}
+
+// StopContainer stops the container and starts it again after downtime. The start runs on a
+// context detached from ctx, so a cancelled run still brings the container back.
+func (c *ContainerCycleInjector) StopContainer(ctx context.Context, containerID string, downtime time.Duration) (err error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if c.executor == nil {
+		return fmt.Errorf("cycle executor is required")
+	}
+	if downtime <= 0 {
+		return fmt.Errorf("stop duration must be greater than zero")
+	}
+
+	if err := c.executor.Stop(ctx, containerID); err != nil {
+		return fmt.Errorf("%w: %v", domainfault.ErrContainerStopFailed, err)
+	}
+	defer func() {
+		if startErr := c.executor.Start(context.WithoutCancel(ctx), containerID); startErr != nil && err == nil {
+			err = fmt.Errorf("start container: %w", startErr)
+		}
+	}()
+
+	return sleepContext(ctx, downtime)
+}
+
+// RestartContainer restarts the container loop.Count times, waiting loop.Interval between
+// restarts. Cancelling ctx ends the loop after the restart in progress.
+func (c *ContainerCycleInjector) RestartContainer(ctx context.Context, containerID string, loop domainfault.RestartLoop) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if c.executor == nil {
+		return fmt.Errorf("cycle executor is required")
+	}
+	if loop.Count <= 0 {
+		return fmt.Errorf("%w: count must be greater than zero", domainfault.ErrInvalidRestartLoop)
+	}
+	if loop.Count > 1 && loop.Interval <= 0 {
+		return fmt.Errorf("%w: interval must be greater than zero", domainfault.ErrInvalidRestartLoop)
+	}
+
+	for i := 0; i < loop.Count; i++ {
+		if i > 0 {
+			if err := sleepContext(ctx, loop.Interval); err != nil {
+				return err
+			}
+		}
+		if err := c.executor.Restart(ctx, containerID); err != nil {
+			return fmt.Errorf("%w: restart %d of %d: %v", domainfault.ErrContainerRestartFailed, i+1, loop.Count, err)
+		}
+	}
+
+	return nil
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
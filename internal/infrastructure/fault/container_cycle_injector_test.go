@@ -0,0 +1,84 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type mockCycleExecutor struct {
+	calls      []string
+	restartErr error
+}
+
+func (m *mockCycleExecutor) Stop(_ context.Context, containerID string) error {
+	m.calls = append(m.calls, "stop "+containerID)
+	return nil
+}
+
+func (m *mockCycleExecutor) Start(ctx context.Context, containerID string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	m.calls = append(m.calls, "start "+containerID)
+	return nil
+}
+
+func (m *mockCycleExecutor) Restart(_ context.Context, containerID string) error {
+	m.calls = append(m.calls, "restart "+containerID)
+	return m.restartErr
+}
+
+func TestContainerCycleInjector_StartsAfterCancelledStop(t *testing.T) {
+	exec := &mockCycleExecutor{}
+	injector := NewContainerCycleInjector(exec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := injector.StopContainer(ctx, "api", time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(exec.calls) != 2 || exec.calls[0] != "stop api" || exec.calls[1] != "start api" {
+		t.Fatalf("expected stop then start, got %#v", exec.calls)
+	}
+}
+
+func TestContainerCycleInjector_RestartLoop(t *testing.T) {
+	exec := &mockCycleExecutor{}
+	injector := NewContainerCycleInjector(exec)
+
+	err := injector.RestartContainer(context.Background(), "api", domainfault.RestartLoop{Count: 3, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("RestartContainer returned error: %v", err)
+	}
+	if len(exec.calls) != 3 {
+		t.Fatalf("expected 3 restarts, got %#v", exec.calls)
+	}
+}
+
+func TestContainerCycleInjector_RestartLoopStopsOnError(t *testing.T) {
+	exec := &mockCycleExecutor{restartErr: errors.New("docker failure")}
+	injector := NewContainerCycleInjector(exec)
+
+	err := injector.RestartContainer(context.Background(), "api", domainfault.RestartLoop{Count: 3, Interval: time.Millisecond})
+	if !errors.Is(err, domainfault.ErrContainerRestartFailed) {
+		t.Fatalf("expected ErrContainerRestartFailed, got %v", err)
+	}
+	if len(exec.calls) != 1 {
+		t.Fatalf("expected the loop to stop after the first failure, got %#v", exec.calls)
+	}
+}
+
+func TestContainerCycleInjector_RejectsMissingInterval(t *testing.T) {
+	injector := NewContainerCycleInjector(&mockCycleExecutor{})
+
+	err := injector.RestartContainer(context.Background(), "api", domainfault.RestartLoop{Count: 2})
+	if !errors.Is(err, domainfault.ErrInvalidRestartLoop) {
+		t.Fatalf("expected ErrInvalidRestartLoop, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type pauseExecutor interface {
+	Pause(ctx context.Context, containerID string) error
+	Unpause(ctx context.Context, containerID string) error
+}
+
+// ContainerPauseInjector freezes a container with the Docker pause API.
+type ContainerPauseInjector struct {
+	executor pauseExecutor
+}
+
+func NewContainerPauseInjector(executor pauseExecutor) *ContainerPauseInjector {
+	return &ContainerPauseInjector{executor: executor}
+}
+
+// PauseContainer pauses the container for duration. The unpause runs on a context detached
+// from ctx, so a cancelled run still leaves the container thawed.
+func (c *ContainerPauseInjector) PauseContainer(ctx context.Context, containerID string, duration time.Duration) (err error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if c.executor == nil {
+		return fmt.Errorf("pause executor is required")
+	}
+	if duration <= 0 {
+		return fmt.Errorf("pause duration must be greater than zero")
+	}
+
+	if err := c.executor.Pause(ctx, containerID); err != nil {
+		return fmt.Errorf("%w: %v", domainfault.ErrContainerPauseFailed, err)
+	}
+	defer func() {
+		if unpauseErr := c.executor.Unpause(context.WithoutCancel(ctx), containerID); unpauseErr != nil && err == nil {
+			err = fmt.Errorf("unpause container: %w", unpauseErr)
+		}
+	}()
+
+	return sleepContext(ctx, duration)
+}
+
+func (c *ContainerPauseInjector) RevertPause(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if c.executor == nil {
+		return fmt.Errorf("pause executor is required")
+	}
+
+	if err := c.executor.Unpause(ctx, containerID); err != nil {
+		return fmt.Errorf("unpause container: %w", err)
+	}
+
+	return nil
+}
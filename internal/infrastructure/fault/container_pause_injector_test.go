@@ -0,0 +1,69 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type mockPauseExecutor struct {
+	calls    []string
+	pauseErr error
+}
+
+func (m *mockPauseExecutor) Pause(_ context.Context, containerID string) error {
+	m.calls = append(m.calls, "pause "+containerID)
+	return m.pauseErr
+}
+
+func (m *mockPauseExecutor) Unpause(ctx context.Context, containerID string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	m.calls = append(m.calls, "unpause "+containerID)
+	return nil
+}
+
+func TestContainerPauseInjector_UnpausesAfterDuration(t *testing.T) {
+	exec := &mockPauseExecutor{}
+	injector := NewContainerPauseInjector(exec)
+
+	if err := injector.PauseContainer(context.Background(), "db", time.Millisecond); err != nil {
+		t.Fatalf("PauseContainer returned error: %v", err)
+	}
+	if len(exec.calls) != 2 || exec.calls[0] != "pause db" || exec.calls[1] != "unpause db" {
+		t.Fatalf("unexpected calls %#v", exec.calls)
+	}
+}
+
+func TestContainerPauseInjector_UnpausesOnCancel(t *testing.T) {
+	exec := &mockPauseExecutor{}
+	injector := NewContainerPauseInjector(exec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := injector.PauseContainer(ctx, "db", time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(exec.calls) != 2 || exec.calls[1] != "unpause db" {
+		t.Fatalf("expected unpause after cancel, got %#v", exec.calls)
+	}
+}
+
+func TestContainerPauseInjector_WrapsExecutorErrors(t *testing.T) {
+	exec := &mockPauseExecutor{pauseErr: errors.New("docker failure")}
+	injector := NewContainerPauseInjector(exec)
+
+	err := injector.PauseContainer(context.Background(), "db", time.Second)
+	if !errors.Is(err, domainfault.ErrContainerPauseFailed) {
+		t.Fatalf("expected ErrContainerPauseFailed, got %v", err)
+	}
+	if len(exec.calls) != 1 {
+		t.Fatalf("expected no unpause after a failed pause, got %#v", exec.calls)
+	}
+}
@@ -0,0 +1,78 @@
+package fault
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	cpuBurnHelper = "cpu-burn"
+	// cpuBurnPeriod is the duty cycle window; within it each worker spins for load percent
+	// and sleeps for the rest.
+	cpuBurnPeriod = 100 * time.Millisecond
+)
+
+func cpuBurnArgs(workers int, load float64, duration time.Duration) []string {
+	return helperArgs(cpuBurnHelper,
+		"-workers", fmt.Sprint(workers),
+		"-load", fmt.Sprint(load),
+		"-duration", duration.String(),
+	)
+}
+
+func runCPUBurn(args []string) error {
+	flags := flag.NewFlagSet(cpuBurnHelper, flag.ContinueOnError)
+	workers := flags.Int("workers", 1, "busy goroutines")
+	load := flags.Float64("load", 100, "percent of each period spent spinning")
+	duration := flags.Duration("duration", 0, "how long to burn")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *workers <= 0 || *load <= 0 || *load > 100 || *duration <= 0 {
+		return fmt.Errorf("invalid cpu burn parameters")
+	}
+
+	runtime.GOMAXPROCS(*workers)
+	deadline := time.Now().Add(*duration)
+	busy := time.Duration(float64(cpuBurnPeriod) * *load / 100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			burn(deadline, busy)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func burn(deadline time.Time, busy time.Duration) {
+	for time.Now().Before(deadline) {
+		start := time.Now()
+		for time.Since(start) < busy {
+		}
+		if idle := cpuBurnPeriod - busy; idle > 0 {
+			time.Sleep(idle)
+		}
+	}
+}
+
+func validateCPUStress(stress domainfault.CPUStress) error {
+	if stress.Workers <= 0 {
+		return domainfault.ErrInvalidWorkerCount
+	}
+	if stress.Load <= 0 || stress.Load > 100 {
+		return domainfault.ErrInvalidPercentage
+	}
+	if stress.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return nil
+}
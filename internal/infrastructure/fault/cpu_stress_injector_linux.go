@@ -0,0 +1,75 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// CPUStressInjector runs a CPU burner helper inside the target container's cgroup, so the
+// load competes with the container's own processes for its CPU quota and shares.
+type CPUStressInjector struct {
+	pidResolver PIDResolver
+}
+
+func NewCPUStressInjector(pidResolver PIDResolver) *CPUStressInjector {
+	return &CPUStressInjector{pidResolver: pidResolver}
+}
+
+func (c *CPUStressInjector) StressCPU(ctx context.Context, containerID string, stress domainfault.CPUStress) error {
+	if err := validateCPUStress(stress); err != nil {
+		return err
+	}
+
+	dir, err := c.cgroupDir(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := startHelper(dir, cpuBurnArgs(stress.Workers, stress.Load, stress.Duration))
+	if err != nil {
+		return fmt.Errorf("stress cpu in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+
+	if err := waitHelper(ctx, cmd); err != nil {
+		return fmt.Errorf("stress cpu in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+	return nil
+}
+
+func (c *CPUStressInjector) RevertCPUStress(ctx context.Context, containerID string) error {
+	dir, err := c.cgroupDir(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	if err := killHelpers(dir, cpuBurnHelper); err != nil {
+		return fmt.Errorf("revert cpu stress in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+	return nil
+}
+
+func (c *CPUStressInjector) cgroupDir(ctx context.Context, containerID string) (string, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return "", domainfault.ErrInvalidContainerID
+	}
+	if c.pidResolver == nil {
+		return "", fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := c.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	dir, err := containerCgroupDir(pid, "cpu")
+	if err != nil {
+		return "", fmt.Errorf("locate cgroup of container %q: %w", containerID, err)
+	}
+	return dir, nil
+}
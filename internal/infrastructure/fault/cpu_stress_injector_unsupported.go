@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type CPUStressInjector struct{}
+
+func NewCPUStressInjector(_ PIDResolver) *CPUStressInjector {
+	return &CPUStressInjector{}
+}
+
+func (c *CPUStressInjector) StressCPU(ctx context.Context, containerID string, stress domainfault.CPUStress) error {
+	_ = ctx
+	_ = containerID
+	_ = stress
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (c *CPUStressInjector) RevertCPUStress(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,139 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	// scopedBand is the extra prio band that holds the netem qdisc. The default priomap only
+	// uses bands 1-3, so nothing reaches band 4 unless a destination filter sends it there.
+	scopedBand   = "1:4"
+	scopedPrioV4 = "1"
+	scopedPrioV6 = "2"
+)
+
+var defaultPriomap = []string{"1", "2", "2", "2", "1", "2", "0", "0", "1", "1", "1", "1", "1", "1", "1", "1"}
+
+// resolveDestination turns the hosts of a destination into CIDRs. IPs become host routes and
+// container names are resolved to every address they have.
+func resolveDestination(ctx context.Context, resolver AddressResolver, to domainfault.Destination) ([]string, error) {
+	if len(to.Hosts) == 0 && len(to.Ports) == 0 {
+		return nil, domainfault.ErrEmptyDestination
+	}
+	for _, port := range to.Ports {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("%w: %d", domainfault.ErrInvalidPort, port)
+		}
+	}
+
+	var cidrs []string
+	for _, raw := range to.Hosts {
+		host := strings.TrimSpace(raw)
+		if host == "" {
+			return nil, domainfault.ErrInvalidDestination
+		}
+		if _, network, err := net.ParseCIDR(host); err == nil {
+			cidrs = append(cidrs, network.String())
+			continue
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			cidrs = append(cidrs, hostCIDR(ip))
+			continue
+		}
+
+		if resolver == nil {
+			return nil, fmt.Errorf("address resolver is required to resolve destination %q", host)
+		}
+		ips, err := resolver.ContainerIPs(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("resolve addresses for destination %q: %w", host, err)
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("%w: %q", domainfault.ErrPeerAddressUnavailable, host)
+		}
+		for _, raw := range ips {
+			if ip := net.ParseIP(raw); ip != nil {
+				cidrs = append(cidrs, hostCIDR(ip))
+			}
+		}
+	}
+
+	return cidrs, nil
+}
+
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// scopedNetemArgs renders the tc invocations for a netem qdisc that only sees traffic to the
+// given CIDRs and ports: a prio root with an extra band, the netem qdisc in that band and u32
+// filters steering matching packets into it. Empty cidrs match every address, empty ports
+// every port.
+func scopedNetemArgs(iface string, netemArgs []string, cidrs []string, ports []int) [][]string {
+	root := []string{"qdisc", "add", "dev", iface, "root", "handle", "1:", "prio", "bands", "4", "priomap"}
+	root = append(root, defaultPriomap...)
+
+	child := []string{"qdisc", "add", "dev", iface, "parent", scopedBand, "handle", "40:", "netem"}
+	child = append(child, netemArgs...)
+
+	commands := [][]string{root, child}
+
+	var v4, v6 []string
+	for _, cidr := range cidrs {
+		if strings.Contains(cidr, ":") {
+			v6 = append(v6, cidr)
+		} else {
+			v4 = append(v4, cidr)
+		}
+	}
+
+	families := []struct {
+		protocol string
+		prio     string
+		selector string
+		cidrs    []string
+	}{
+		{"ip", scopedPrioV4, "ip", v4},
+		{"ipv6", scopedPrioV6, "ip6", v6},
+	}
+	for _, family := range families {
+		// A family without addresses only gets filters when the whole destination is port based.
+		if len(family.cidrs) == 0 && len(cidrs) > 0 {
+			continue
+		}
+
+		dsts := family.cidrs
+		if len(dsts) == 0 {
+			dsts = []string{""}
+		}
+		dports := ports
+		if len(dports) == 0 {
+			dports = []int{0}
+		}
+
+		for _, dst := range dsts {
+			for _, port := range dports {
+				filter := []string{"filter", "add", "dev", iface, "parent", "1:", "protocol", family.protocol, "prio", family.prio, "u32"}
+				if dst != "" {
+					filter = append(filter, "match", family.selector, "dst", dst)
+				}
+				if port != 0 {
+					filter = append(filter, "match", family.selector, "dport", strconv.Itoa(port), "0xffff")
+				}
+				filter = append(filter, "flowid", scopedBand)
+				commands = append(commands, filter)
+			}
+		}
+	}
+
+	return commands
+}
@@ -0,0 +1,73 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type staticAddressResolver map[string][]string
+
+func (r staticAddressResolver) ContainerIPs(_ context.Context, containerID string) ([]string, error) {
+	return r[containerID], nil
+}
+
+func TestResolveDestination(t *testing.T) {
+	resolver := staticAddressResolver{"postgres": {"172.18.0.3", "fd00::3"}}
+
+	cidrs, err := resolveDestination(context.Background(), resolver, domainfault.Destination{
+		Hosts: []string{"postgres", "10.1.2.3", "10.0.0.0/8"},
+	})
+	if err != nil {
+		t.Fatalf("resolveDestination returned error: %v", err)
+	}
+
+	want := []string{"172.18.0.3/32", "fd00::3/128", "10.1.2.3/32", "10.0.0.0/8"}
+	if !reflect.DeepEqual(cidrs, want) {
+		t.Fatalf("expected %v, got %v", want, cidrs)
+	}
+}
+
+func TestResolveDestination_Errors(t *testing.T) {
+	resolver := staticAddressResolver{}
+
+	if _, err := resolveDestination(context.Background(), resolver, domainfault.Destination{}); !errors.Is(err, domainfault.ErrEmptyDestination) {
+		t.Fatalf("expected ErrEmptyDestination, got %v", err)
+	}
+	if _, err := resolveDestination(context.Background(), resolver, domainfault.Destination{Ports: []int{70000}}); !errors.Is(err, domainfault.ErrInvalidPort) {
+		t.Fatalf("expected ErrInvalidPort, got %v", err)
+	}
+	if _, err := resolveDestination(context.Background(), resolver, domainfault.Destination{Hosts: []string{"ghost"}}); !errors.Is(err, domainfault.ErrPeerAddressUnavailable) {
+		t.Fatalf("expected ErrPeerAddressUnavailable, got %v", err)
+	}
+}
+
+func TestScopedNetemArgs_HostsAndPorts(t *testing.T) {
+	commands := scopedNetemArgs("eth0", []string{"delay", "100ms"}, []string{"172.18.0.3/32", "fd00::3/128"}, []int{5432})
+
+	want := [][]string{
+		{"qdisc", "add", "dev", "eth0", "root", "handle", "1:", "prio", "bands", "4", "priomap", "1", "2", "2", "2", "1", "2", "0", "0", "1", "1", "1", "1", "1", "1", "1", "1"},
+		{"qdisc", "add", "dev", "eth0", "parent", "1:4", "handle", "40:", "netem", "delay", "100ms"},
+		{"filter", "add", "dev", "eth0", "parent", "1:", "protocol", "ip", "prio", "1", "u32", "match", "ip", "dst", "172.18.0.3/32", "match", "ip", "dport", "5432", "0xffff", "flowid", "1:4"},
+		{"filter", "add", "dev", "eth0", "parent", "1:", "protocol", "ipv6", "prio", "2", "u32", "match", "ip6", "dst", "fd00::3/128", "match", "ip6", "dport", "5432", "0xffff", "flowid", "1:4"},
+	}
+	if !reflect.DeepEqual(commands, want) {
+		t.Fatalf("expected %v, got %v", want, commands)
+	}
+}
+
+func TestScopedNetemArgs_PortsOnlyCoverBothFamilies(t *testing.T) {
+	commands := scopedNetemArgs("eth0", []string{"loss", "10%"}, nil, []int{5432})
+
+	filters := commands[2:]
+	want := [][]string{
+		{"filter", "add", "dev", "eth0", "parent", "1:", "protocol", "ip", "prio", "1", "u32", "match", "ip", "dport", "5432", "0xffff", "flowid", "1:4"},
+		{"filter", "add", "dev", "eth0", "parent", "1:", "protocol", "ipv6", "prio", "2", "u32", "match", "ip6", "dport", "5432", "0xffff", "flowid", "1:4"},
+	}
+	if !reflect.DeepEqual(filters, want) {
+		t.Fatalf("expected %v, got %v", want, filters)
+	}
+}
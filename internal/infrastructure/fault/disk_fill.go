@@ -0,0 +1,51 @@
+package fault
+
+import (
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ballastName is the file a disk fill writes into the target directory.
+const ballastName = ".chaos-dock-ballast"
+
+// ballastBytes returns how much to write for fill on a filesystem of total bytes, of which
+// free bytes are unused and avail bytes are writable by unprivileged users. A percentage fills
+// the filesystem up to that share, counting blocks reserved for root as free; the result
+// never exceeds the available space.
+func ballastBytes(fill domainfault.DiskFill, total uint64, free uint64, avail uint64) uint64 {
+	size := fill.Bytes
+	if fill.Percent > 0 {
+		used := total - free
+		target := uint64(float64(total) * fill.Percent / 100)
+		if target <= used {
+			return 0
+		}
+		size = target - used
+	}
+	if size > avail {
+		size = avail
+	}
+	return size
+}
+
+func validateDiskFill(fill domainfault.DiskFill) error {
+	if strings.TrimSpace(fill.Path) == "" && strings.TrimSpace(fill.Volume) == "" {
+		return domainfault.ErrInvalidDiskFill
+	}
+	if fill.Bytes == 0 && fill.Percent == 0 {
+		return domainfault.ErrInvalidDiskFill
+	}
+	if fill.Percent < 0 || fill.Percent > 100 {
+		return domainfault.ErrInvalidPercentage
+	}
+	if fill.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return nil
+}
+
+// newBallastLedger records, per container, the directories that hold a ballast file.
+func newBallastLedger() ledger[string] {
+	return newLedger("disk-fill", func(dir string) string { return dir })
+}
@@ -0,0 +1,192 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const ballastChunk = 1024 * 1024
+
+// DiskFillInjector writes ballast files into a container's filesystem through
+// /proc/<pid>/root, so it works without a shell or dd in the image.
+type DiskFillInjector struct {
+	pidResolver    PIDResolver
+	volumeResolver VolumeResolver
+	ledger         ledger[string]
+}
+
+func NewDiskFillInjector(pidResolver PIDResolver, volumeResolver VolumeResolver) *DiskFillInjector {
+	return &DiskFillInjector{
+		pidResolver:    pidResolver,
+		volumeResolver: volumeResolver,
+		ledger:         newBallastLedger(),
+	}
+}
+
+func (d *DiskFillInjector) FillDisk(ctx context.Context, containerID string, fill domainfault.DiskFill) (err error) {
+	if err := validateDiskFill(fill); err != nil {
+		return err
+	}
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if d.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	dir := path.Clean("/" + strings.TrimSpace(fill.Path))
+	if strings.TrimSpace(fill.Volume) != "" {
+		if d.volumeResolver == nil {
+			return fmt.Errorf("volume resolver is required")
+		}
+		dir, err = d.volumeResolver.VolumeDestination(ctx, containerID, fill.Volume)
+		if err != nil {
+			return fmt.Errorf("resolve volume %q of container %q: %w", fill.Volume, containerID, err)
+		}
+	}
+
+	pid, err := d.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	dirFD, err := openContainerDir(pid, dir)
+	if err != nil {
+		return fmt.Errorf("open %s in container %q: %w", dir, containerID, err)
+	}
+	defer unix.Close(dirFD)
+
+	var stat unix.Statfs_t
+	if err := unix.Fstatfs(dirFD, &stat); err != nil {
+		return fmt.Errorf("stat filesystem of %s in container %q: %w", dir, containerID, err)
+	}
+	size := ballastBytes(fill, stat.Blocks*uint64(stat.Bsize), stat.Bfree*uint64(stat.Bsize), stat.Bavail*uint64(stat.Bsize))
+
+	if err := d.ledger.record(containerID, dir); err != nil {
+		return err
+	}
+	defer func() {
+		if removeErr := removeBallast(dirFD); removeErr != nil {
+			err = errors.Join(err, fmt.Errorf("remove ballast from %s in container %q: %w", dir, containerID, removeErr))
+			return
+		}
+		if forgetErr := d.ledger.forget(containerID, dir); forgetErr != nil {
+			err = errors.Join(err, forgetErr)
+		}
+	}()
+
+	if err := writeBallast(ctx, dirFD, size); err != nil {
+		return fmt.Errorf("fill %s in container %q: %w", dir, containerID, err)
+	}
+
+	holdCtx, cancel := context.WithTimeout(ctx, fill.Duration)
+	defer cancel()
+	<-holdCtx.Done()
+	return nil
+}
+
+// RevertDiskFill removes every ballast recorded for the container, including those left by a
+// chaos-dock process that stopped before its fault ended.
+func (d *DiskFillInjector) RevertDiskFill(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+
+	dirs, err := d.ledger.entries(containerID)
+	if err != nil || len(dirs) == 0 {
+		return err
+	}
+	if d.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := d.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	var errs []error
+	for _, dir := range dirs {
+		dirFD, err := openContainerDir(pid, dir)
+		if err == nil {
+			err = removeBallast(dirFD)
+			unix.Close(dirFD)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("remove ballast from %s in container %q: %w", dir, containerID, err))
+			continue
+		}
+		if err := d.ledger.forget(containerID, dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// openContainerDir opens dir as seen from inside the container. Symlinks are resolved within
+// the container root, so they cannot point the ballast at the host filesystem.
+func openContainerDir(pid int, dir string) (int, error) {
+	rootFD, err := unix.Open("/proc/"+strconv.Itoa(pid)+"/root", unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, fmt.Errorf("open root of process %d: %w", pid, err)
+	}
+	defer unix.Close(rootFD)
+
+	return unix.Openat2(rootFD, strings.TrimPrefix(dir, "/"), &unix.OpenHow{
+		Flags:   unix.O_RDONLY | unix.O_DIRECTORY | unix.O_CLOEXEC,
+		Resolve: unix.RESOLVE_IN_ROOT,
+	})
+}
+
+// writeBallast writes size bytes of zeros. Running out of space is the point of the fault,
+// so ENOSPC ends the write without an error.
+func writeBallast(ctx context.Context, dirFD int, size uint64) error {
+	fd, err := unix.Openat(dirFD, ballastName, unix.O_WRONLY|unix.O_CREAT|unix.O_TRUNC|unix.O_CLOEXEC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	chunk := make([]byte, ballastChunk)
+	for written := uint64(0); written < size; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := uint64(len(chunk))
+		if size-written < n {
+			n = size - written
+		}
+		wrote, err := unix.Write(fd, chunk[:n])
+		if errors.Is(err, unix.ENOSPC) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		written += uint64(wrote)
+	}
+	if err := unix.Fsync(fd); err != nil && !errors.Is(err, unix.ENOSPC) {
+		return err
+	}
+	return nil
+}
+
+func removeBallast(dirFD int) error {
+	err := unix.Unlinkat(dirFD, ballastName, 0)
+	if errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	return err
+}
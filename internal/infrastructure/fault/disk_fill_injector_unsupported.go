@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type DiskFillInjector struct{}
+
+func NewDiskFillInjector(_ PIDResolver, _ VolumeResolver) *DiskFillInjector {
+	return &DiskFillInjector{}
+}
+
+func (d *DiskFillInjector) FillDisk(ctx context.Context, containerID string, fill domainfault.DiskFill) error {
+	_ = ctx
+	_ = containerID
+	_ = fill
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (d *DiskFillInjector) RevertDiskFill(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,75 @@
+package fault
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestBallastBytes(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+
+	cases := []struct {
+		name               string
+		fill               domainfault.DiskFill
+		total, free, avail uint64
+		want               uint64
+	}{
+		{"absolute", domainfault.DiskFill{Bytes: gib}, 10 * gib, 5 * gib, 5 * gib, gib},
+		{"absolute capped by available space", domainfault.DiskFill{Bytes: 8 * gib}, 10 * gib, 5 * gib, 4 * gib, 4 * gib},
+		{"percent fills up to target", domainfault.DiskFill{Percent: 90}, 10 * gib, 5 * gib, 5 * gib, 4 * gib},
+		{"percent ignores reserved blocks", domainfault.DiskFill{Percent: 80}, 10 * gib, 5 * gib, 4 * gib, 3 * gib},
+		{"percent capped by available space", domainfault.DiskFill{Percent: 100}, 10 * gib, 5 * gib, 4 * gib, 4 * gib},
+		{"percent already reached", domainfault.DiskFill{Percent: 40}, 10 * gib, 5 * gib, 5 * gib, 0},
+	}
+	for _, tc := range cases {
+		if got := ballastBytes(tc.fill, tc.total, tc.free, tc.avail); got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestValidateDiskFill(t *testing.T) {
+	if err := validateDiskFill(domainfault.DiskFill{Volume: "pgdata", Percent: 95, Duration: time.Minute}); err != nil {
+		t.Fatalf("validateDiskFill returned error: %v", err)
+	}
+	if err := validateDiskFill(domainfault.DiskFill{Bytes: 1024, Duration: time.Minute}); !errors.Is(err, domainfault.ErrInvalidDiskFill) {
+		t.Fatalf("expected ErrInvalidDiskFill, got %v", err)
+	}
+}
+
+func TestBallastLedger(t *testing.T) {
+	ledger := newBallastLedger()
+	ledger.dir = filepath.Join(t.TempDir(), "disk-fill")
+
+	if err := ledger.record("postgres", "/var/lib/postgresql/data"); err != nil {
+		t.Fatalf("record returned error: %v", err)
+	}
+	if err := ledger.record("postgres", "/tmp"); err != nil {
+		t.Fatalf("record returned error: %v", err)
+	}
+	if err := ledger.record("postgres", "/tmp"); err != nil {
+		t.Fatalf("record returned error: %v", err)
+	}
+
+	dirs, err := ledger.entries("postgres")
+	if err != nil {
+		t.Fatalf("entries returned error: %v", err)
+	}
+	if want := []string{"/var/lib/postgresql/data", "/tmp"}; !reflect.DeepEqual(dirs, want) {
+		t.Fatalf("expected %v, got %v", want, dirs)
+	}
+
+	for _, dir := range dirs {
+		if err := ledger.forget("postgres", dir); err != nil {
+			t.Fatalf("forget returned error: %v", err)
+		}
+	}
+	if dirs, _ := ledger.entries("postgres"); len(dirs) != 0 {
+		t.Fatalf("expected empty ledger, got %v", dirs)
+	}
+}
@@ -0,0 +1,124 @@
+package fault
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	dnsHeaderSize    = 12
+	dnsRcodeSrvFail  = 2
+	dnsRcodeNXDomain = 3
+)
+
+var errMalformedDNSQuery = errors.New("malformed dns query")
+
+// parseDNSQuestion returns the lower-cased name of the first question in a DNS query and the
+// offset right after that question (name, type and class).
+func parseDNSQuestion(msg []byte) (string, int, error) {
+	if len(msg) < dnsHeaderSize || binary.BigEndian.Uint16(msg[4:6]) == 0 {
+		return "", 0, errMalformedDNSQuery
+	}
+
+	var labels []string
+	offset := dnsHeaderSize
+	for {
+		if offset >= len(msg) {
+			return "", 0, errMalformedDNSQuery
+		}
+		length := int(msg[offset])
+		offset++
+		if length == 0 {
+			break
+		}
+		// Queries never use compression pointers (top two bits set) for the question name.
+		if length&0xC0 != 0 || offset+length > len(msg) {
+			return "", 0, errMalformedDNSQuery
+		}
+		labels = append(labels, strings.ToLower(string(msg[offset:offset+length])))
+		offset += length
+	}
+
+	offset += 4 // QTYPE + QCLASS
+	if offset > len(msg) {
+		return "", 0, errMalformedDNSQuery
+	}
+
+	return strings.Join(labels, "."), offset, nil
+}
+
+// dnsErrorResponse answers query with rcode and no records, echoing its question.
+func dnsErrorResponse(query []byte, rcode byte) ([]byte, error) {
+	_, end, err := parseDNSQuestion(query)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]byte, end)
+	copy(resp, query[:end])
+
+	// QR=1, keep opcode and RD, RA=1, set RCODE.
+	resp[2] = 0x80 | (query[2] & 0x79)
+	resp[3] = 0x80 | (rcode & 0x0F)
+	binary.BigEndian.PutUint16(resp[4:6], 1)
+	binary.BigEndian.PutUint16(resp[6:8], 0)
+	binary.BigEndian.PutUint16(resp[8:10], 0)
+	binary.BigEndian.PutUint16(resp[10:12], 0)
+
+	return resp, nil
+}
+
+// matchesDomain reports whether name equals one of domains or is a subdomain of it.
+// An empty domain list matches every name.
+func matchesDomain(name string, domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	for _, raw := range domains {
+		domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+		domain = strings.TrimPrefix(domain, "*.")
+		if domain == "" || domain == "*" {
+			return true
+		}
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstIPv4Nameserver returns the first IPv4 nameserver listed in resolv.conf content.
+func firstIPv4Nameserver(resolvConf string) (net.IP, bool) {
+	for _, line := range strings.Split(resolvConf, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		if ip := net.ParseIP(fields[1]); ip != nil && ip.To4() != nil {
+			return ip, true
+		}
+	}
+	return nil, false
+}
+
+func validateDNSFault(dnsFault domainfault.DNSFault) error {
+	switch strings.ToLower(strings.TrimSpace(dnsFault.Mode)) {
+	case "nxdomain", "servfail":
+	case "delay":
+		if dnsFault.Delay <= 0 {
+			return domainfault.ErrInvalidLatencyDuration
+		}
+	default:
+		return domainfault.ErrInvalidDNSMode
+	}
+	if dnsFault.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const dnsRevertTimeout = 10 * time.Second
+
+// DNSFaultInjector redirects a container's UDP DNS queries to an in-process responder that
+// runs inside the container's network namespace. Matching names get NXDOMAIN, SERVFAIL or a
+// delayed answer; everything else is relayed to the container's own nameserver.
+type DNSFaultInjector struct {
+	pidResolver PIDResolver
+	iptables    iptablesRunner
+
+	mu     sync.Mutex
+	active map[string]context.CancelFunc // ends the hold of a running fault
+}
+
+func NewDNSFaultInjector(pidResolver PIDResolver) *DNSFaultInjector {
+	return &DNSFaultInjector{
+		pidResolver: pidResolver,
+		iptables:    newIPTablesRunner(),
+		active:      make(map[string]context.CancelFunc),
+	}
+}
+
+// InjectDNSFault holds the fault for dnsFault.Duration or until ctx is canceled, and always
+// removes the redirect before returning.
+func (d *DNSFaultInjector) InjectDNSFault(ctx context.Context, containerID string, dnsFault domainfault.DNSFault) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if err := validateDNSFault(dnsFault); err != nil {
+		return err
+	}
+	if d.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := d.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	resolvConf, err := os.ReadFile(fmt.Sprintf("/proc/%d/root/etc/resolv.conf", pid))
+	if err != nil {
+		return fmt.Errorf("read resolv.conf of container %q: %w", containerID, err)
+	}
+	nameserver, ok := firstIPv4Nameserver(string(resolvConf))
+	if !ok {
+		return fmt.Errorf("%w: %q", domainfault.ErrDNSResolverUnavailable, containerID)
+	}
+
+	var listen, upstream net.PacketConn
+	err = inNetworkNamespace(pid, func() error {
+		var err error
+		listen, err = net.ListenPacket("udp4", "127.0.0.1:0")
+		if err != nil {
+			return fmt.Errorf("listen for redirected queries: %w", err)
+		}
+		upstream, err = markedListenConfig(dnsBypassMark).ListenPacket(ctx, "udp4", "0.0.0.0:0")
+		if err != nil {
+			_ = listen.Close()
+			return fmt.Errorf("open upstream socket: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("open dns sockets in container %q: %w", containerID, err)
+	}
+
+	holdCtx, release := context.WithTimeout(ctx, dnsFault.Duration)
+	defer release()
+	d.track(containerID, release)
+	defer d.untrack(containerID)
+
+	serveCtx, stopServing := context.WithCancel(context.WithoutCancel(ctx))
+	responder := newDNSResponder(listen, upstream, &net.UDPAddr{IP: nameserver, Port: 53}, dnsFault)
+	var serveErr error
+	served := make(chan struct{})
+	go func() {
+		serveErr = responder.serve(serveCtx)
+		close(served)
+	}()
+	defer func() {
+		stopServing()
+		<-served
+	}()
+
+	// Deferred after stopServing so the redirect is removed while the responder still answers.
+	// It gets a fresh deadline: the redirect must go even when ctx is already canceled.
+	defer func() {
+		revertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), dnsRevertTimeout)
+		defer cancel()
+		_ = d.iptables.removeChains(revertCtx, pid, "nat", dnsRedirectChain)
+	}()
+
+	if err := d.iptables.removeChains(ctx, pid, "nat", dnsRedirectChain); err != nil {
+		return fmt.Errorf("reset dns redirect in container %q: %w", containerID, err)
+	}
+	port := listen.LocalAddr().(*net.UDPAddr).Port
+	for _, rule := range dnsRedirectRules(port) {
+		if err := d.iptables.run(ctx, pid, "iptables", rule); err != nil {
+			return fmt.Errorf("redirect dns in container %q: %w", containerID, err)
+		}
+	}
+
+	select {
+	case <-holdCtx.Done():
+		return nil
+	case <-served:
+		if serveErr != nil {
+			return fmt.Errorf("serve dns fault in container %q: %w", containerID, serveErr)
+		}
+		return nil
+	}
+}
+
+// RevertDNSFault stops a running responder for containerID and removes the redirect rules,
+// including rules left behind by a chaos-dock process that exited mid-fault.
+func (d *DNSFaultInjector) RevertDNSFault(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if d.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	d.mu.Lock()
+	if cancel, ok := d.active[containerID]; ok {
+		cancel()
+	}
+	d.mu.Unlock()
+
+	pid, err := d.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	if err := d.iptables.removeChains(ctx, pid, "nat", dnsRedirectChain); err != nil {
+		return fmt.Errorf("revert dns fault in container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+func (d *DNSFaultInjector) track(containerID string, cancel context.CancelFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.active[containerID] = cancel
+}
+
+func (d *DNSFaultInjector) untrack(containerID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.active, containerID)
+}
+
+func markedListenConfig(mark int) *net.ListenConfig {
+	return &net.ListenConfig{
+		Control: func(_, _ string, conn syscall.RawConn) error {
+			var sockErr error
+			err := conn.Control(func(fd uintptr) {
+				sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK, mark)
+			})
+			if err != nil {
+				return err
+			}
+			return sockErr
+		},
+	}
+}
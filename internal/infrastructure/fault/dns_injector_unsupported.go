@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type DNSFaultInjector struct{}
+
+func NewDNSFaultInjector(_ PIDResolver) *DNSFaultInjector {
+	return &DNSFaultInjector{}
+}
+
+func (d *DNSFaultInjector) InjectDNSFault(ctx context.Context, containerID string, dnsFault domainfault.DNSFault) error {
+	_ = ctx
+	_ = containerID
+	_ = dnsFault
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (d *DNSFaultInjector) RevertDNSFault(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,143 @@
+package fault
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const maxDNSMessageSize = 65535
+
+// dnsResponder answers matching queries according to a DNS fault and relays every other
+// query to the container's original resolver. Relayed queries get a fresh transaction ID so
+// concurrent clients cannot collide; the pending table is bounded by the 16-bit ID space.
+type dnsResponder struct {
+	listen   net.PacketConn
+	upstream net.PacketConn
+	resolver net.Addr
+	fault    domainfault.DNSFault
+
+	mu      sync.Mutex
+	nextID  uint16
+	pending map[uint16]pendingDNSQuery
+}
+
+type pendingDNSQuery struct {
+	client net.Addr
+	id     uint16
+}
+
+func newDNSResponder(listen net.PacketConn, upstream net.PacketConn, resolver net.Addr, fault domainfault.DNSFault) *dnsResponder {
+	return &dnsResponder{
+		listen:   listen,
+		upstream: upstream,
+		resolver: resolver,
+		fault:    fault,
+		pending:  make(map[uint16]pendingDNSQuery),
+	}
+}
+
+// serve handles queries until ctx is canceled, then closes both sockets.
+func (d *dnsResponder) serve(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		_ = d.listen.Close()
+		_ = d.upstream.Close()
+	})
+	defer stop()
+
+	go d.relayResponses()
+
+	buf := make([]byte, maxDNSMessageSize)
+	for {
+		n, client, err := d.listen.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		query := make([]byte, n)
+		copy(query, buf[:n])
+		go d.handle(ctx, query, client)
+	}
+}
+
+func (d *dnsResponder) handle(ctx context.Context, query []byte, client net.Addr) {
+	name, _, err := parseDNSQuestion(query)
+	if err != nil || !matchesDomain(name, d.fault.Domains) {
+		d.forward(query, client)
+		return
+	}
+
+	switch strings.ToLower(d.fault.Mode) {
+	case "nxdomain":
+		d.reply(query, client, dnsRcodeNXDomain)
+	case "servfail":
+		d.reply(query, client, dnsRcodeSrvFail)
+	case "delay":
+		timer := time.NewTimer(d.fault.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			d.forward(query, client)
+		}
+	default:
+		d.forward(query, client)
+	}
+}
+
+func (d *dnsResponder) reply(query []byte, client net.Addr, rcode byte) {
+	resp, err := dnsErrorResponse(query, rcode)
+	if err != nil {
+		return
+	}
+	_, _ = d.listen.WriteTo(resp, client)
+}
+
+func (d *dnsResponder) forward(query []byte, client net.Addr) {
+	if len(query) < dnsHeaderSize {
+		return
+	}
+
+	d.mu.Lock()
+	id := d.nextID
+	d.nextID++
+	d.pending[id] = pendingDNSQuery{client: client, id: binary.BigEndian.Uint16(query[0:2])}
+	d.mu.Unlock()
+
+	binary.BigEndian.PutUint16(query[0:2], id)
+	_, _ = d.upstream.WriteTo(query, d.resolver)
+}
+
+func (d *dnsResponder) relayResponses() {
+	buf := make([]byte, maxDNSMessageSize)
+	for {
+		n, _, err := d.upstream.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if n < dnsHeaderSize {
+			continue
+		}
+
+		id := binary.BigEndian.Uint16(buf[0:2])
+		d.mu.Lock()
+		query, ok := d.pending[id]
+		delete(d.pending, id)
+		d.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		binary.BigEndian.PutUint16(buf[0:2], query.id)
+		_, _ = d.listen.WriteTo(buf[:n], query.client)
+	}
+}
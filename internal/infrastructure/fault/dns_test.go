@@ -0,0 +1,194 @@
+package fault
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// buildDNSQuery encodes a recursive A query for name.
+func buildDNSQuery(id uint16, name string) []byte {
+	msg := make([]byte, dnsHeaderSize)
+	binary.BigEndian.PutUint16(msg[0:2], id)
+	msg[2] = 0x01 // RD
+	binary.BigEndian.PutUint16(msg[4:6], 1)
+
+	for _, label := range splitLabels(name) {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0, 0, 1, 0, 1) // root, QTYPE=A, QCLASS=IN
+	return msg
+}
+
+func splitLabels(name string) []string {
+	var labels []string
+	start := 0
+	for i := 0; i <= len(name); i++ {
+		if i == len(name) || name[i] == '.' {
+			labels = append(labels, name[start:i])
+			start = i + 1
+		}
+	}
+	return labels
+}
+
+func TestParseDNSQuestion(t *testing.T) {
+	query := buildDNSQuery(7, "API.Example.com")
+
+	name, end, err := parseDNSQuestion(query)
+	if err != nil {
+		t.Fatalf("parseDNSQuestion returned error: %v", err)
+	}
+	if name != "api.example.com" {
+		t.Fatalf("unexpected name %q", name)
+	}
+	if end != len(query) {
+		t.Fatalf("expected question to end at %d, got %d", len(query), end)
+	}
+}
+
+func TestParseDNSQuestion_Malformed(t *testing.T) {
+	query := buildDNSQuery(7, "example.com")
+	if _, _, err := parseDNSQuestion(query[:len(query)-3]); err == nil {
+		t.Fatalf("expected error for truncated question")
+	}
+	if _, _, err := parseDNSQuestion(query[:8]); err == nil {
+		t.Fatalf("expected error for truncated header")
+	}
+}
+
+func TestDNSErrorResponse(t *testing.T) {
+	query := buildDNSQuery(0xBEEF, "example.com")
+
+	resp, err := dnsErrorResponse(query, dnsRcodeNXDomain)
+	if err != nil {
+		t.Fatalf("dnsErrorResponse returned error: %v", err)
+	}
+	if binary.BigEndian.Uint16(resp[0:2]) != 0xBEEF {
+		t.Fatalf("expected transaction id to be echoed")
+	}
+	if resp[2]&0x80 == 0 || resp[2]&0x01 == 0 {
+		t.Fatalf("expected QR and RD flags, got %08b", resp[2])
+	}
+	if resp[3]&0x0F != dnsRcodeNXDomain {
+		t.Fatalf("expected NXDOMAIN rcode, got %d", resp[3]&0x0F)
+	}
+}
+
+func TestMatchesDomain(t *testing.T) {
+	domains := []string{"Example.com.", "*.internal"}
+
+	for name, want := range map[string]bool{
+		"example.com":      true,
+		"api.example.com":  true,
+		"notexample.com":   false,
+		"db.svc.internal":  true,
+		"registry.io":      false,
+		"example.com.evil": false,
+	} {
+		if got := matchesDomain(name, domains); got != want {
+			t.Fatalf("matchesDomain(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if !matchesDomain("anything.test", nil) {
+		t.Fatalf("expected empty domain list to match every name")
+	}
+}
+
+func TestDNSResponder_FaultsMatchingAndRelaysOthers(t *testing.T) {
+	upstreamServer, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen upstream: %v", err)
+	}
+	defer upstreamServer.Close()
+
+	// Fake resolver: answers every query with NOERROR.
+	go func() {
+		buf := make([]byte, maxDNSMessageSize)
+		for {
+			n, addr, err := upstreamServer.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp := append([]byte(nil), buf[:n]...)
+			resp[2] |= 0x80
+			_, _ = upstreamServer.WriteTo(resp, addr)
+		}
+	}()
+
+	listen, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen responder: %v", err)
+	}
+	upstream, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen relay: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	responder := newDNSResponder(listen, upstream, upstreamServer.LocalAddr(), domainfault.DNSFault{
+		Mode:    "nxdomain",
+		Domains: []string{"postgres.internal"},
+	})
+	done := make(chan error, 1)
+	go func() { done <- responder.serve(ctx) }()
+
+	client, err := net.Dial("udp", listen.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("dial responder: %v", err)
+	}
+	defer client.Close()
+
+	exchange := func(query []byte) []byte {
+		t.Helper()
+		if _, err := client.Write(query); err != nil {
+			t.Fatalf("write query: %v", err)
+		}
+		_ = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+		buf := make([]byte, maxDNSMessageSize)
+		n, err := client.Read(buf)
+		if err != nil {
+			t.Fatalf("read response: %v", err)
+		}
+		return buf[:n]
+	}
+
+	faulted := exchange(buildDNSQuery(1, "db.postgres.internal"))
+	if faulted[3]&0x0F != dnsRcodeNXDomain || binary.BigEndian.Uint16(faulted[0:2]) != 1 {
+		t.Fatalf("expected NXDOMAIN for id 1, got rcode %d id %d", faulted[3]&0x0F, binary.BigEndian.Uint16(faulted[0:2]))
+	}
+
+	relayed := exchange(buildDNSQuery(2, "redis.internal"))
+	if relayed[3]&0x0F != 0 || binary.BigEndian.Uint16(relayed[0:2]) != 2 {
+		t.Fatalf("expected relayed NOERROR for id 2, got rcode %d id %d", relayed[3]&0x0F, binary.BigEndian.Uint16(relayed[0:2]))
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serve returned error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("serve did not stop after cancel")
+	}
+}
+
+func TestFirstIPv4Nameserver(t *testing.T) {
+	resolvConf := "# generated by docker\nsearch svc.local\nnameserver fd00::1\nnameserver 127.0.0.11\nnameserver 8.8.8.8\n"
+
+	ip, ok := firstIPv4Nameserver(resolvConf)
+	if !ok || ip.String() != "127.0.0.11" {
+		t.Fatalf("expected 127.0.0.11, got %v (ok=%v)", ip, ok)
+	}
+	if _, ok := firstIPv4Nameserver("options ndots:0\n"); ok {
+		t.Fatalf("expected no nameserver")
+	}
+}
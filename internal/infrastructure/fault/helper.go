@@ -0,0 +1,66 @@
+package fault
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// helperCommand is the hidden first argument that makes the chaos-dock binary act as a
+// workload helper. Helpers are started by the injectors inside the target container's
+// cgroup, so the work they do is charged to that container.
+const helperCommand = "__chaos-dock-helper"
+
+// helperCgroupEnv names the cgroup directory a helper joins before it does any work.
+const helperCgroupEnv = "CHAOS_DOCK_HELPER_CGROUP"
+
+var helpers = map[string]func(args []string) error{
+	cpuBurnHelper:   runCPUBurn,
+	memoryHogHelper: runMemoryHog,
+	pidHogHelper:    runPidHog,
+	fdHogHelper:     runFDHog,
+}
+
+// IsHelperInvocation reports whether the process was started as a workload helper.
+func IsHelperInvocation(args []string) bool {
+	return len(args) > 1 && args[1] == helperCommand
+}
+
+// RunHelper runs the workload helper selected by args, which are the full process arguments.
+func RunHelper(args []string) error {
+	if !IsHelperInvocation(args) || len(args) < 3 {
+		return fmt.Errorf("helper kind is required")
+	}
+
+	run, ok := helpers[args[2]]
+	if !ok {
+		return fmt.Errorf("unknown helper %q", args[2])
+	}
+	if dir := os.Getenv(helperCgroupEnv); dir != "" {
+		if err := joinCgroup(dir); err != nil {
+			return err
+		}
+	}
+	return run(args[3:])
+}
+
+// helperArgs renders the arguments that start helper kind.
+func helperArgs(kind string, args ...string) []string {
+	return append([]string{helperCommand, kind}, args...)
+}
+
+// isHelperCmdline reports whether a NUL separated /proc/<pid>/cmdline belongs to helper kind.
+func isHelperCmdline(cmdline []byte, kind string) bool {
+	fields := bytes.Split(cmdline, []byte{0})
+	return len(fields) > 2 && string(fields[1]) == helperCommand && string(fields[2]) == kind
+}
+
+// joinCgroup moves the calling process into the cgroup at dir.
+func joinCgroup(dir string) error {
+	if err := os.WriteFile(filepath.Join(dir, "cgroup.procs"), []byte(strconv.Itoa(os.Getpid())), 0o644); err != nil {
+		return fmt.Errorf("join cgroup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package fault
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsHelperCmdline(t *testing.T) {
+	args := append([]string{"/usr/local/bin/chaos-dock"}, cpuBurnArgs(2, 50, time.Second)...)
+	cmdline := []byte(strings.Join(args, "\x00") + "\x00")
+
+	if !isHelperCmdline(cmdline, cpuBurnHelper) {
+		t.Fatalf("expected %q to be a cpu burn helper", args)
+	}
+	if isHelperCmdline([]byte("/usr/local/bin/chaos-dock\x00-run-once\x00"), cpuBurnHelper) {
+		t.Fatalf("regular invocation must not be a helper")
+	}
+}
+
+func TestRunHelper_CPUBurn(t *testing.T) {
+	args := append([]string{"chaos-dock"}, cpuBurnArgs(1, 20, 50*time.Millisecond)...)
+	if !IsHelperInvocation(args) {
+		t.Fatalf("expected %q to be a helper invocation", args)
+	}
+
+	start := time.Now()
+	if err := RunHelper(args); err != nil {
+		t.Fatalf("RunHelper returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("cpu burn returned after %s, before its duration", elapsed)
+	}
+}
+
+func TestRunHelper_JoinsCgroupFirst(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(helperCgroupEnv, dir)
+
+	args := append([]string{"chaos-dock"}, cpuBurnArgs(1, 20, 10*time.Millisecond)...)
+	if err := RunHelper(args); err != nil {
+		t.Fatalf("RunHelper returned error: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+	if err != nil {
+		t.Fatalf("read cgroup.procs: %v", err)
+	}
+	if string(raw) != strconv.Itoa(os.Getpid()) {
+		t.Fatalf("expected pid %d in cgroup.procs, got %q", os.Getpid(), raw)
+	}
+}
+
+func TestRunHelper_StopsWhenCgroupCannotBeJoined(t *testing.T) {
+	t.Setenv(helperCgroupEnv, filepath.Join(t.TempDir(), "missing"))
+
+	args := append([]string{"chaos-dock"}, cpuBurnArgs(1, 20, time.Minute)...)
+	start := time.Now()
+	if err := RunHelper(args); err == nil {
+		t.Fatalf("expected join error")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("helper ran for %s outside its cgroup", elapsed)
+	}
+}
+
+func TestRunHelper_Unknown(t *testing.T) {
+	if err := RunHelper([]string{"chaos-dock", helperCommand, "mystery"}); err == nil {
+		t.Fatalf("expected unknown helper error")
+	}
+}
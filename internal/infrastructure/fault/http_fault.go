@@ -0,0 +1,21 @@
+package fault
+
+import (
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	"github.com/lekhanpro/chaos-dock/internal/infrastructure/httpfault"
+)
+
+func validateHTTPFault(httpFault domainfault.HTTPFault) error {
+	if len(httpFault.Ports) == 0 {
+		return domainfault.ErrInvalidPort
+	}
+	for _, port := range httpFault.Ports {
+		if port < 1 || port > 65535 {
+			return domainfault.ErrInvalidPort
+		}
+	}
+	if httpFault.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return httpfault.Validate(httpFault)
+}
@@ -0,0 +1,195 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	"github.com/lekhanpro/chaos-dock/internal/infrastructure/httpfault"
+)
+
+const (
+	httpProxyReadHeaderTimeout = 30 * time.Second
+	httpRevertTimeout          = 10 * time.Second
+)
+
+// HTTPFaultInjector places an httpfault.Proxy in front of container ports. The proxy listens
+// inside the container's network namespace and an iptables REDIRECT sends incoming
+// connections for the ports to it; the proxy reaches the service on the container's own
+// address, which the redirect does not cover.
+type HTTPFaultInjector struct {
+	pidResolver PIDResolver
+	iptables    iptablesRunner
+
+	mu     sync.Mutex
+	active map[string]context.CancelFunc // ends the hold of a running fault
+}
+
+func NewHTTPFaultInjector(pidResolver PIDResolver) *HTTPFaultInjector {
+	return &HTTPFaultInjector{
+		pidResolver: pidResolver,
+		iptables:    newIPTablesRunner(),
+		active:      make(map[string]context.CancelFunc),
+	}
+}
+
+// InjectHTTPFault holds the fault for httpFault.Duration or until ctx is canceled, and always
+// removes the redirect before returning.
+func (h *HTTPFaultInjector) InjectHTTPFault(ctx context.Context, containerID string, httpFault domainfault.HTTPFault) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if err := validateHTTPFault(httpFault); err != nil {
+		return err
+	}
+	if h.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := h.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	listeners := make([]net.Listener, 0, len(httpFault.Ports))
+	err = inNetworkNamespace(pid, func() error {
+		for range httpFault.Ports {
+			ln, err := net.Listen("tcp4", "0.0.0.0:0")
+			if err != nil {
+				return fmt.Errorf("listen for redirected connections: %w", err)
+			}
+			listeners = append(listeners, ln)
+		}
+		return nil
+	})
+	if err != nil {
+		for _, ln := range listeners {
+			_ = ln.Close()
+		}
+		return fmt.Errorf("open http proxy in container %q: %w", containerID, err)
+	}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			var conn net.Conn
+			err := inNetworkNamespace(pid, func() error {
+				var err error
+				conn, err = (&net.Dialer{}).DialContext(ctx, network, addr)
+				return err
+			})
+			return conn, err
+		},
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     30 * time.Second,
+	}
+	defer transport.CloseIdleConnections()
+
+	holdCtx, release := context.WithTimeout(ctx, httpFault.Duration)
+	defer release()
+	h.track(containerID, release)
+	defer h.untrack(containerID)
+
+	servers := make([]*http.Server, 0, len(listeners))
+	redirects := make([]portRedirect, 0, len(listeners))
+	serveErrs := make(chan error, len(listeners))
+	for i, ln := range listeners {
+		proxy, err := httpfault.NewProxy(httpFault, httpfault.LocalTarget(httpFault.Ports[i]), transport)
+		if err != nil {
+			for _, ln := range listeners[i:] {
+				_ = ln.Close()
+			}
+			for _, srv := range servers {
+				_ = srv.Close()
+			}
+			return err
+		}
+
+		srv := &http.Server{Handler: proxy, ReadHeaderTimeout: httpProxyReadHeaderTimeout}
+		servers = append(servers, srv)
+		redirects = append(redirects, portRedirect{port: httpFault.Ports[i], proxyPort: ln.Addr().(*net.TCPAddr).Port})
+		go func(ln net.Listener) {
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErrs <- err
+			}
+		}(ln)
+	}
+	defer func() {
+		for _, srv := range servers {
+			_ = srv.Close()
+		}
+	}()
+
+	// Deferred after closing the servers so the redirect is removed while the proxy still
+	// answers. It gets a fresh deadline: the redirect must go even when ctx is already canceled.
+	defer func() {
+		revertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), httpRevertTimeout)
+		defer cancel()
+		_ = h.iptables.removeChains(revertCtx, pid, "nat", httpRedirectChain)
+	}()
+
+	if err := h.iptables.removeChains(ctx, pid, "nat", httpRedirectChain); err != nil {
+		return fmt.Errorf("reset http redirect in container %q: %w", containerID, err)
+	}
+	for _, rule := range httpRedirectRules(redirects) {
+		if err := h.iptables.run(ctx, pid, "iptables", rule); err != nil {
+			return fmt.Errorf("redirect http in container %q: %w", containerID, err)
+		}
+	}
+
+	select {
+	case <-holdCtx.Done():
+		return nil
+	case err := <-serveErrs:
+		return fmt.Errorf("serve http fault in container %q: %w", containerID, err)
+	}
+}
+
+// RevertHTTPFault stops a running proxy for containerID and removes the redirect rules,
+// including rules left behind by a chaos-dock process that exited mid-fault.
+func (h *HTTPFaultInjector) RevertHTTPFault(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if h.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	h.mu.Lock()
+	if cancel, ok := h.active[containerID]; ok {
+		cancel()
+	}
+	h.mu.Unlock()
+
+	pid, err := h.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	if err := h.iptables.removeChains(ctx, pid, "nat", httpRedirectChain); err != nil {
+		return fmt.Errorf("revert http fault in container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+func (h *HTTPFaultInjector) track(containerID string, cancel context.CancelFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.active[containerID] = cancel
+}
+
+func (h *HTTPFaultInjector) untrack(containerID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.active, containerID)
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type HTTPFaultInjector struct{}
+
+func NewHTTPFaultInjector(_ PIDResolver) *HTTPFaultInjector {
+	return &HTTPFaultInjector{}
+}
+
+func (h *HTTPFaultInjector) InjectHTTPFault(ctx context.Context, containerID string, httpFault domainfault.HTTPFault) error {
+	_ = ctx
+	_ = containerID
+	_ = httpFault
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (h *HTTPFaultInjector) RevertHTTPFault(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
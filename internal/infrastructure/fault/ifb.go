@@ -0,0 +1,50 @@
+package fault
+
+import (
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ifbInterfaceName is the intermediate functional block device that ingress traffic is
+// redirected through, so it can be shaped by an ordinary root qdisc.
+const ifbInterfaceName = "chaosdock-ifb"
+
+// shapedDirections reports which sides of the interface a shaping fault applies to.
+// Shaping faults default to egress, which is what a root qdisc covers on its own.
+func shapedDirections(direction string) (egress bool, ingress bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "", "egress":
+		return true, false, nil
+	case "ingress":
+		return false, true, nil
+	case "both":
+		return true, true, nil
+	default:
+		return false, false, domainfault.ErrInvalidDirection
+	}
+}
+
+// ingressRedirectArgs renders the tc filter that mirrors every packet arriving on iface to
+// the egress side of the ifb device.
+func ingressRedirectArgs(iface string, ifb string) []string {
+	return []string{
+		"filter", "add", "dev", iface, "parent", "ffff:", "protocol", "all",
+		"u32", "match", "u32", "0", "0",
+		"action", "mirred", "egress", "redirect", "dev", ifb,
+	}
+}
+
+func isMissingDevice(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "cannot find device")
+}
+
+func isExistingDevice(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "file exists")
+}
@@ -0,0 +1,47 @@
+package fault
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestShapedDirections(t *testing.T) {
+	cases := []struct {
+		direction       string
+		egress, ingress bool
+	}{
+		{"", true, false},
+		{"egress", true, false},
+		{"Ingress", false, true},
+		{"both", true, true},
+	}
+	for _, tc := range cases {
+		egress, ingress, err := shapedDirections(tc.direction)
+		if err != nil {
+			t.Fatalf("shapedDirections(%q) returned error: %v", tc.direction, err)
+		}
+		if egress != tc.egress || ingress != tc.ingress {
+			t.Fatalf("shapedDirections(%q) = %v, %v, want %v, %v", tc.direction, egress, ingress, tc.egress, tc.ingress)
+		}
+	}
+
+	if _, _, err := shapedDirections("sideways"); !errors.Is(err, domainfault.ErrInvalidDirection) {
+		t.Fatalf("expected ErrInvalidDirection, got %v", err)
+	}
+}
+
+func TestIngressRedirectArgs(t *testing.T) {
+	args := ingressRedirectArgs("eth0", ifbInterfaceName)
+
+	want := []string{
+		"filter", "add", "dev", "eth0", "parent", "ffff:", "protocol", "all",
+		"u32", "match", "u32", "0", "0",
+		"action", "mirred", "egress", "redirect", "dev", "chaosdock-ifb",
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
@@ -0,0 +1,43 @@
+package fault
+
+import (
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ContainerRuntime is what the built-in injectors need from the container engine. The Docker
+// runtime implements all of it.
+type ContainerRuntime interface {
+	PIDResolver
+	AddressResolver
+	VolumeResolver
+	IOLimitSource
+	networkAttacher
+	pauseExecutor
+	cycleExecutor
+	killExecutor
+	domainfault.OOMInspector
+}
+
+// NewInjectors returns every built-in injector backed by runtime.
+func NewInjectors(runtime ContainerRuntime) domainfault.Injectors {
+	return domainfault.Injectors{
+		Injector:      NewNetworkLatencyInjector(runtime, runtime),
+		Partitioner:   NewNetworkPartitionInjector(runtime, runtime),
+		Blackholer:    NewPortBlackholeInjector(runtime),
+		Disconnector:  NewNetworkDisconnectInjector(runtime),
+		DNS:           NewDNSFaultInjector(runtime),
+		HTTP:          NewHTTPFaultInjector(runtime),
+		Resetter:      NewTCPResetInjector(runtime, runtime),
+		CPU:           NewCPUStressInjector(runtime),
+		Memory:        NewMemoryStressInjector(runtime),
+		IO:            NewIOThrottleInjector(runtime, runtime),
+		Disk:          NewDiskFillInjector(runtime, runtime),
+		Exhauster:     NewResourceExhaustionInjector(runtime),
+		Pauser:        NewContainerPauseInjector(runtime),
+		Cycler:        NewContainerCycleInjector(runtime),
+		Killer:        NewContainerKillInjector(runtime),
+		ProcessKiller: NewProcessKillInjector(runtime),
+		Clock:         NewClockSkewInjector(runtime),
+		Inspector:     runtime,
+	}
+}
@@ -0,0 +1,92 @@
+package fault
+
+import (
+	"strconv"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ioMaxKeys are the cgroup v2 io.max limits, in the order they are rendered.
+var ioMaxKeys = []string{"rbps", "wbps", "riops", "wiops"}
+
+// parseIOMax returns the limits io.max holds for device (MAJ:MIN). Devices without an entry
+// are unlimited, which is reported as zero values.
+func parseIOMax(content string, device string) domainfault.IOThrottle {
+	var limits domainfault.IOThrottle
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != device {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, raw, ok := strings.Cut(field, "=")
+			if !ok || raw == "max" {
+				continue
+			}
+			value, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				continue
+			}
+			if field := ioLimitField(&limits, key); field != nil {
+				*field = value
+			}
+		}
+	}
+	return limits
+}
+
+// ioMaxLine renders limits for device into an io.max line. Only the non-zero limits are
+// written, so the others keep their value; with all set, zero limits are written as max.
+func ioMaxLine(device string, limits domainfault.IOThrottle, all bool) string {
+	parts := []string{device}
+	for _, key := range ioMaxKeys {
+		value := *ioLimitField(&limits, key)
+		switch {
+		case value > 0:
+			parts = append(parts, key+"="+strconv.FormatUint(value, 10))
+		case all:
+			parts = append(parts, key+"=max")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ioMaxDevices lists the MAJ:MIN devices that have an entry in io.max.
+func ioMaxDevices(content string) []string {
+	var devices []string
+	for _, line := range strings.Split(content, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			devices = append(devices, fields[0])
+		}
+	}
+	return devices
+}
+
+func ioLimitField(limits *domainfault.IOThrottle, key string) *uint64 {
+	switch key {
+	case "rbps":
+		return &limits.ReadBPS
+	case "wbps":
+		return &limits.WriteBPS
+	case "riops":
+		return &limits.ReadIOPS
+	case "wiops":
+		return &limits.WriteIOPS
+	default:
+		return nil
+	}
+}
+
+func validateIOThrottle(throttle domainfault.IOThrottle) error {
+	if strings.TrimSpace(throttle.Device) == "" {
+		return domainfault.ErrInvalidIOThrottle
+	}
+	if throttle.ReadBPS == 0 && throttle.WriteBPS == 0 && throttle.ReadIOPS == 0 && throttle.WriteIOPS == 0 {
+		return domainfault.ErrInvalidIOThrottle
+	}
+	if throttle.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return nil
+}
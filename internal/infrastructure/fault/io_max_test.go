@@ -0,0 +1,49 @@
+package fault
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestParseIOMax(t *testing.T) {
+	content := "8:0 rbps=1048576 wbps=max riops=max wiops=200\n259:0 rbps=max wbps=max riops=max wiops=max\n"
+
+	got := parseIOMax(content, "8:0")
+	want := domainfault.IOThrottle{ReadBPS: 1048576, WriteIOPS: 200}
+	if got != want {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+
+	if got := parseIOMax(content, "8:16"); got != (domainfault.IOThrottle{}) {
+		t.Fatalf("expected unlimited device, got %#v", got)
+	}
+	if devices := ioMaxDevices(content); !reflect.DeepEqual(devices, []string{"8:0", "259:0"}) {
+		t.Fatalf("unexpected devices %v", devices)
+	}
+}
+
+func TestIOMaxLine(t *testing.T) {
+	limits := domainfault.IOThrottle{ReadBPS: 1048576, WriteIOPS: 100}
+
+	if line := ioMaxLine("8:0", limits, false); line != "8:0 rbps=1048576 wiops=100" {
+		t.Fatalf("unexpected partial line %q", line)
+	}
+	if line := ioMaxLine("8:0", limits, true); line != "8:0 rbps=1048576 wbps=max riops=max wiops=100" {
+		t.Fatalf("unexpected full line %q", line)
+	}
+}
+
+func TestValidateIOThrottle(t *testing.T) {
+	valid := domainfault.IOThrottle{Device: "/dev/sda", WriteBPS: 1024, Duration: time.Second}
+	if err := validateIOThrottle(valid); err != nil {
+		t.Fatalf("validateIOThrottle returned error: %v", err)
+	}
+
+	if err := validateIOThrottle(domainfault.IOThrottle{Device: "/dev/sda", Duration: time.Second}); !errors.Is(err, domainfault.ErrInvalidIOThrottle) {
+		t.Fatalf("expected ErrInvalidIOThrottle, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// capturedIOLimit is the io.max entry of a device before it was throttled.
+type capturedIOLimit struct {
+	ioMax  string
+	device string
+	limits domainfault.IOThrottle
+}
+
+// ioLimitKey identifies a throttled device (MAJ:MIN) of a container.
+type ioLimitKey struct {
+	containerID string
+	device      string
+}
+
+// IOThrottleInjector lowers per-device limits in the cgroup v2 io.max file of a container.
+// Docker's update API cannot change device throttles of a running container, so the cgroup
+// is written directly.
+type IOThrottleInjector struct {
+	pidResolver PIDResolver
+	limitSource IOLimitSource
+
+	write    func(path string, line string) error
+	mu       sync.Mutex
+	captured map[ioLimitKey]capturedIOLimit
+}
+
+func NewIOThrottleInjector(pidResolver PIDResolver, limitSource IOLimitSource) *IOThrottleInjector {
+	return &IOThrottleInjector{
+		pidResolver: pidResolver,
+		limitSource: limitSource,
+		write:       writeIOMax,
+		captured:    make(map[ioLimitKey]capturedIOLimit),
+	}
+}
+
+func (i *IOThrottleInjector) ThrottleIO(ctx context.Context, containerID string, throttle domainfault.IOThrottle) error {
+	if err := validateIOThrottle(throttle); err != nil {
+		return err
+	}
+	containerID = strings.TrimSpace(containerID)
+
+	ioMax, err := i.ioMaxPath(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	device, err := blockDeviceNumber(throttle.Device)
+	if err != nil {
+		return err
+	}
+
+	raw, err := os.ReadFile(ioMax)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+
+	key := ioLimitKey{containerID: containerID, device: device}
+	i.capture(key, ioMax, string(raw))
+
+	if err := i.write(ioMax, ioMaxLine(device, throttle, false)); err != nil {
+		_ = i.restoreCaptured(key)
+		return fmt.Errorf("throttle io of container %q: %w", containerID, err)
+	}
+
+	holdCtx, cancel := context.WithTimeout(ctx, throttle.Duration)
+	<-holdCtx.Done()
+	cancel()
+
+	if err := i.restoreCaptured(key); err != nil {
+		return fmt.Errorf("restore io limits of container %q: %w", containerID, err)
+	}
+	return nil
+}
+
+// RevertIOThrottle restores the limits captured by ThrottleIO. Without a capture, for example
+// in a separate panic run, every device in io.max is reset to the limits Docker configured.
+func (i *IOThrottleInjector) RevertIOThrottle(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	i.mu.Lock()
+	var keys []ioLimitKey
+	for key := range i.captured {
+		if key.containerID == containerID {
+			keys = append(keys, key)
+		}
+	}
+	i.mu.Unlock()
+	if len(keys) > 0 {
+		var errs []error
+		for _, key := range keys {
+			if err := i.restoreCaptured(key); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return fmt.Errorf("revert io throttle of container %q: %w", containerID, err)
+		}
+		return nil
+	}
+
+	ioMax, err := i.ioMaxPath(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(ioMax)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+
+	configured := make(map[string]domainfault.IOThrottle)
+	if i.limitSource != nil {
+		limits, err := i.limitSource.ConfiguredIOLimits(ctx, containerID)
+		if err != nil {
+			return fmt.Errorf("read configured io limits of container %q: %w", containerID, err)
+		}
+		for _, limit := range limits {
+			device, err := blockDeviceNumber(limit.Device)
+			if err != nil {
+				continue
+			}
+			configured[device] = limit
+		}
+	}
+
+	var errs []error
+	for _, device := range ioMaxDevices(string(raw)) {
+		if err := i.write(ioMax, ioMaxLine(device, configured[device], true)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("revert io throttle of container %q: %w", containerID, err)
+	}
+	return nil
+}
+
+// capture records the io.max entry of a device from the raw io.max content. The first capture
+// is kept, so a repeated throttle does not record throttled limits as the originals.
+func (i *IOThrottleInjector) capture(key ioLimitKey, ioMax string, raw string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.captured[key]; !ok {
+		i.captured[key] = capturedIOLimit{ioMax: ioMax, device: key.device, limits: parseIOMax(raw, key.device)}
+	}
+}
+
+func (i *IOThrottleInjector) restoreCaptured(key ioLimitKey) error {
+	i.mu.Lock()
+	captured, ok := i.captured[key]
+	delete(i.captured, key)
+	i.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	err := i.write(captured.ioMax, ioMaxLine(captured.device, captured.limits, true))
+	if errors.Is(err, os.ErrNotExist) {
+		// The container is gone, and its limits with it.
+		return nil
+	}
+	return err
+}
+
+func (i *IOThrottleInjector) ioMaxPath(ctx context.Context, containerID string) (string, error) {
+	if containerID == "" {
+		return "", domainfault.ErrInvalidContainerID
+	}
+	if i.pidResolver == nil {
+		return "", fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := i.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	dir, err := containerCgroupDir(pid, "io")
+	if err != nil {
+		return "", fmt.Errorf("locate cgroup of container %q: %w", containerID, err)
+	}
+
+	ioMax := filepath.Join(dir, "io.max")
+	if _, err := os.Stat(ioMax); err != nil {
+		return "", fmt.Errorf("%w: io.max is missing, a cgroup v2 host with the io controller is required", domainfault.ErrCgroupUnavailable)
+	}
+	return ioMax, nil
+}
+
+// blockDeviceNumber returns the MAJ:MIN number of a block device path.
+func blockDeviceNumber(path string) (string, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", domainfault.ErrBlockDeviceUnavailable, path, err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return "", fmt.Errorf("%w: %s is not a block device", domainfault.ErrBlockDeviceUnavailable, path)
+	}
+	return fmt.Sprintf("%d:%d", unix.Major(uint64(stat.Rdev)), unix.Minor(uint64(stat.Rdev))), nil
+}
+
+func writeIOMax(path string, line string) error {
+	err := os.WriteFile(path, []byte(line), 0o644)
+	if errors.Is(err, os.ErrPermission) {
+		return fmt.Errorf("%w: %v", domainfault.ErrInsufficientPrivileges, err)
+	}
+	return err
+}
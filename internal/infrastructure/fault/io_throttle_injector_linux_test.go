@@ -0,0 +1,46 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRevertIOThrottle_RestoresEveryDevice(t *testing.T) {
+	const ioMax = "/sys/fs/cgroup/system.slice/docker-abc.scope/io.max"
+	raw := "8:0 rbps=1048576 wbps=max riops=max wiops=max\n8:16 rbps=max wbps=max riops=max wiops=500\n"
+
+	var written []string
+	injector := NewIOThrottleInjector(nil, nil)
+	injector.write = func(path string, line string) error {
+		if path != ioMax {
+			t.Fatalf("unexpected io.max path %q", path)
+		}
+		written = append(written, line)
+		return nil
+	}
+
+	injector.capture(ioLimitKey{containerID: "db", device: "8:0"}, ioMax, raw)
+	injector.capture(ioLimitKey{containerID: "db", device: "8:16"}, ioMax, raw)
+	// A repeated throttle of 8:0 reads the throttled limits, which must not replace the originals.
+	injector.capture(ioLimitKey{containerID: "db", device: "8:0"}, ioMax, "8:0 rbps=1024 wbps=1024 riops=max wiops=max\n")
+
+	if err := injector.RevertIOThrottle(context.Background(), "db"); err != nil {
+		t.Fatalf("RevertIOThrottle returned error: %v", err)
+	}
+
+	sort.Strings(written)
+	want := []string{
+		"8:0 rbps=1048576 wbps=max riops=max wiops=max",
+		"8:16 rbps=max wbps=max riops=max wiops=500",
+	}
+	if !reflect.DeepEqual(written, want) {
+		t.Fatalf("expected %q to be restored, got %q", want, written)
+	}
+	if len(injector.captured) != 0 {
+		t.Fatalf("expected captures to be cleared, got %#v", injector.captured)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type IOThrottleInjector struct{}
+
+func NewIOThrottleInjector(_ PIDResolver, _ IOLimitSource) *IOThrottleInjector {
+	return &IOThrottleInjector{}
+}
+
+func (i *IOThrottleInjector) ThrottleIO(ctx context.Context, containerID string, throttle domainfault.IOThrottle) error {
+	_ = ctx
+	_ = containerID
+	_ = throttle
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (i *IOThrottleInjector) RevertIOThrottle(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,187 @@
+package fault
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	partitionChain        = "CHAOS-DOCK-PARTITION"
+	blackholeIngressChain = "CHAOS-DOCK-BLACKHOLE-IN"
+	blackholeEgressChain  = "CHAOS-DOCK-BLACKHOLE-OUT"
+	dnsRedirectChain      = "CHAOS-DOCK-DNS"
+	httpRedirectChain     = "CHAOS-DOCK-HTTP"
+
+	// dnsBypassMark tags the responder's own upstream queries so they skip the redirect.
+	dnsBypassMark = 0xc4d0
+)
+
+// splitByFamily groups addresses by the iptables binary that filters them.
+func splitByFamily(addresses []string) map[string][]string {
+	families := make(map[string][]string, 2)
+	for _, raw := range addresses {
+		ip := net.ParseIP(strings.TrimSpace(raw))
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil {
+			families["iptables"] = append(families["iptables"], ip.String())
+		} else {
+			families["ip6tables"] = append(families["ip6tables"], ip.String())
+		}
+	}
+	return families
+}
+
+// partitionRules renders the iptables invocations that create the partition chain, drop
+// traffic from and to every address and hook the chain into INPUT and OUTPUT.
+func partitionRules(addresses []string) [][]string {
+	rules := [][]string{{"-w", "-N", partitionChain}}
+	for _, addr := range addresses {
+		rules = append(rules,
+			[]string{"-w", "-A", partitionChain, "-s", addr, "-j", "DROP"},
+			[]string{"-w", "-A", partitionChain, "-d", addr, "-j", "DROP"},
+		)
+	}
+	rules = append(rules,
+		[]string{"-w", "-I", "INPUT", "1", "-j", partitionChain},
+		[]string{"-w", "-I", "OUTPUT", "1", "-j", partitionChain},
+	)
+	return rules
+}
+
+// chainHook jumps from a built-in chain into one of our chains.
+type chainHook struct {
+	builtin string
+	chain   string
+}
+
+// blackholeRules renders the iptables invocations for a port blackhole. Ingress rules match
+// connections to the container's own ports (INPUT), egress rules match connections the container
+// opens to remote ports (OUTPUT). binary selects the ICMP reject type for UDP.
+func blackholeRules(binary string, blackhole domainfault.PortBlackhole) ([][]string, error) {
+	if len(blackhole.Ports) == 0 {
+		return nil, domainfault.ErrInvalidPort
+	}
+	for _, port := range blackhole.Ports {
+		if port < 1 || port > 65535 {
+			return nil, domainfault.ErrInvalidPort
+		}
+	}
+
+	protocols := make([]string, 0, len(blackhole.Protocols))
+	for _, raw := range blackhole.Protocols {
+		protocol := strings.ToLower(strings.TrimSpace(raw))
+		if protocol != "tcp" && protocol != "udp" {
+			return nil, domainfault.ErrInvalidProtocol
+		}
+		protocols = append(protocols, protocol)
+	}
+	if len(protocols) == 0 {
+		protocols = []string{"tcp"}
+	}
+
+	var hooks []chainHook
+	switch strings.ToLower(strings.TrimSpace(blackhole.Direction)) {
+	case "ingress":
+		hooks = append(hooks, chainHook{"INPUT", blackholeIngressChain})
+	case "egress":
+		hooks = append(hooks, chainHook{"OUTPUT", blackholeEgressChain})
+	case "", "both":
+		hooks = append(hooks,
+			chainHook{"INPUT", blackholeIngressChain},
+			chainHook{"OUTPUT", blackholeEgressChain},
+		)
+	default:
+		return nil, domainfault.ErrInvalidDirection
+	}
+
+	var rules [][]string
+	for _, hook := range hooks {
+		rules = append(rules, []string{"-w", "-N", hook.chain})
+		for _, protocol := range protocols {
+			for _, port := range blackhole.Ports {
+				rule := []string{"-w", "-A", hook.chain, "-p", protocol, "--dport", strconv.Itoa(port)}
+				rules = append(rules, append(rule, blackholeTarget(binary, protocol, blackhole.Reject)...))
+			}
+		}
+		rules = append(rules, []string{"-w", "-I", hook.builtin, "1", "-j", hook.chain})
+	}
+
+	return rules, nil
+}
+
+func blackholeTarget(binary string, protocol string, reject bool) []string {
+	switch {
+	case !reject:
+		return []string{"-j", "DROP"}
+	case protocol == "tcp":
+		return []string{"-j", "REJECT", "--reject-with", "tcp-reset"}
+	case binary == "ip6tables":
+		return []string{"-j", "REJECT", "--reject-with", "icmp6-port-unreachable"}
+	default:
+		return []string{"-j", "REJECT", "--reject-with", "icmp-port-unreachable"}
+	}
+}
+
+// dnsRedirectRules renders the nat rules that send the container's UDP DNS queries to the
+// fault responder listening on 127.0.0.1:port. The chain is inserted ahead of Docker's own
+// embedded DNS rules.
+func dnsRedirectRules(port int) [][]string {
+	return [][]string{
+		{"-w", "-t", "nat", "-N", dnsRedirectChain},
+		{"-w", "-t", "nat", "-A", dnsRedirectChain, "-m", "mark", "--mark", strconv.Itoa(dnsBypassMark), "-j", "RETURN"},
+		{"-w", "-t", "nat", "-A", dnsRedirectChain, "-p", "udp", "--dport", "53", "-j", "REDIRECT", "--to-ports", strconv.Itoa(port)},
+		{"-w", "-t", "nat", "-I", "OUTPUT", "1", "-j", dnsRedirectChain},
+	}
+}
+
+// portRedirect sends connections for a container port to a local proxy port.
+type portRedirect struct {
+	port      int
+	proxyPort int
+}
+
+// httpRedirectRules renders the nat rules that send incoming TCP connections for each
+// container port to the proxy in front of it. Only PREROUTING is hooked, so the proxy's own
+// connections to the service are not redirected again.
+func httpRedirectRules(redirects []portRedirect) [][]string {
+	rules := [][]string{{"-w", "-t", "nat", "-N", httpRedirectChain}}
+	for _, r := range redirects {
+		rules = append(rules, []string{
+			"-w", "-t", "nat", "-A", httpRedirectChain, "-p", "tcp", "--dport", strconv.Itoa(r.port),
+			"-j", "REDIRECT", "--to-ports", strconv.Itoa(r.proxyPort),
+		})
+	}
+	return append(rules, []string{"-w", "-t", "nat", "-I", "PREROUTING", "1", "-j", httpRedirectChain})
+}
+
+// chainCleanupRules unhooks and removes a chain from table. Each step may fail when the chain
+// was never installed, which callers treat as already reverted.
+func chainCleanupRules(table string, chain string) [][]string {
+	rules := [][]string{
+		{"-w", "-t", table, "-D", "INPUT", "-j", chain},
+		{"-w", "-t", table, "-D", "OUTPUT", "-j", chain},
+	}
+	if table == "nat" {
+		rules = append(rules, []string{"-w", "-t", table, "-D", "PREROUTING", "-j", chain})
+	}
+	return append(rules,
+		[]string{"-w", "-t", table, "-F", chain},
+		[]string{"-w", "-t", table, "-X", chain},
+	)
+}
+
+func isMissingChain(err error) bool {
+	if err == nil {
+		return false
+	}
+	raw := strings.ToLower(err.Error())
+	return strings.Contains(raw, "no chain/target/match") ||
+		strings.Contains(raw, "couldn't load target") ||
+		strings.Contains(raw, "does a matching rule exist") ||
+		strings.Contains(raw, "doesn't exist")
+}
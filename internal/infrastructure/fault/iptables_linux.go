@@ -0,0 +1,53 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// iptablesRunner runs the host iptables binaries inside a container network namespace
+// (nsenter --net), so the target image does not need to ship iptables.
+type iptablesRunner struct {
+	nsenter nsenterRunner
+}
+
+func newIPTablesRunner() iptablesRunner {
+	return iptablesRunner{nsenter: newNsenterRunner()}
+}
+
+func (r iptablesRunner) run(ctx context.Context, pid int, binary string, args []string) error {
+	_, err := r.nsenter.run(ctx, pid, namespaceCommand{
+		namespaces: []string{"--net"},
+		binary:     binary,
+		args:       args,
+		missingErr: domainfault.ErrIPTablesMissing,
+		failedErr:  domainfault.ErrIPTablesCommandFailed,
+	})
+	return err
+}
+
+// removeChains unhooks and deletes chains of table for both address families. Missing chains
+// and a missing ip6tables binary are treated as already reverted.
+func (r iptablesRunner) removeChains(ctx context.Context, pid int, table string, chains ...string) error {
+	for _, binary := range []string{"iptables", "ip6tables"} {
+	chains:
+		for _, chain := range chains {
+			for _, rule := range chainCleanupRules(table, chain) {
+				err := r.run(ctx, pid, binary, rule)
+				if err == nil || isMissingChain(err) {
+					continue
+				}
+				if binary == "ip6tables" && errors.Is(err, domainfault.ErrIPTablesMissing) {
+					// Without ip6tables no IPv6 rules can have been installed.
+					break chains
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
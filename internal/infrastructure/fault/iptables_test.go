@@ -0,0 +1,130 @@
+package fault
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestSplitByFamily(t *testing.T) {
+	families := splitByFamily([]string{"172.18.0.3", "fd00::3", "not-an-ip", " 10.0.0.7 "})
+
+	if want := []string{"172.18.0.3", "10.0.0.7"}; !reflect.DeepEqual(families["iptables"], want) {
+		t.Fatalf("expected IPv4 addresses %v, got %v", want, families["iptables"])
+	}
+	if want := []string{"fd00::3"}; !reflect.DeepEqual(families["ip6tables"], want) {
+		t.Fatalf("expected IPv6 addresses %v, got %v", want, families["ip6tables"])
+	}
+}
+
+func TestPartitionRules(t *testing.T) {
+	rules := partitionRules([]string{"172.18.0.3"})
+
+	want := [][]string{
+		{"-w", "-N", partitionChain},
+		{"-w", "-A", partitionChain, "-s", "172.18.0.3", "-j", "DROP"},
+		{"-w", "-A", partitionChain, "-d", "172.18.0.3", "-j", "DROP"},
+		{"-w", "-I", "INPUT", "1", "-j", partitionChain},
+		{"-w", "-I", "OUTPUT", "1", "-j", partitionChain},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("expected %v, got %v", want, rules)
+	}
+}
+
+func TestIsMissingChain(t *testing.T) {
+	if !isMissingChain(errors.New("iptables: No chain/target/match by that name.")) {
+		t.Fatalf("expected missing chain error to be detected")
+	}
+	if isMissingChain(errors.New("permission denied")) {
+		t.Fatalf("unexpected missing chain detection")
+	}
+}
+
+func TestBlackholeRules_EgressReject(t *testing.T) {
+	rules, err := blackholeRules("iptables", domainfault.PortBlackhole{
+		Ports:     []int{6379},
+		Protocols: []string{"TCP", "udp"},
+		Direction: "egress",
+		Reject:    true,
+	})
+	if err != nil {
+		t.Fatalf("blackholeRules returned error: %v", err)
+	}
+
+	want := [][]string{
+		{"-w", "-N", blackholeEgressChain},
+		{"-w", "-A", blackholeEgressChain, "-p", "tcp", "--dport", "6379", "-j", "REJECT", "--reject-with", "tcp-reset"},
+		{"-w", "-A", blackholeEgressChain, "-p", "udp", "--dport", "6379", "-j", "REJECT", "--reject-with", "icmp-port-unreachable"},
+		{"-w", "-I", "OUTPUT", "1", "-j", blackholeEgressChain},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("expected %v, got %v", want, rules)
+	}
+}
+
+func TestBlackholeRules_DefaultsToBothDirectionsDropTCP(t *testing.T) {
+	rules, err := blackholeRules("ip6tables", domainfault.PortBlackhole{Ports: []int{5432}})
+	if err != nil {
+		t.Fatalf("blackholeRules returned error: %v", err)
+	}
+
+	want := [][]string{
+		{"-w", "-N", blackholeIngressChain},
+		{"-w", "-A", blackholeIngressChain, "-p", "tcp", "--dport", "5432", "-j", "DROP"},
+		{"-w", "-I", "INPUT", "1", "-j", blackholeIngressChain},
+		{"-w", "-N", blackholeEgressChain},
+		{"-w", "-A", blackholeEgressChain, "-p", "tcp", "--dport", "5432", "-j", "DROP"},
+		{"-w", "-I", "OUTPUT", "1", "-j", blackholeEgressChain},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("expected %v, got %v", want, rules)
+	}
+}
+
+func TestBlackholeRules_Invalid(t *testing.T) {
+	cases := []struct {
+		blackhole domainfault.PortBlackhole
+		want      error
+	}{
+		{domainfault.PortBlackhole{}, domainfault.ErrInvalidPort},
+		{domainfault.PortBlackhole{Ports: []int{70000}}, domainfault.ErrInvalidPort},
+		{domainfault.PortBlackhole{Ports: []int{80}, Protocols: []string{"sctp"}}, domainfault.ErrInvalidProtocol},
+		{domainfault.PortBlackhole{Ports: []int{80}, Direction: "sideways"}, domainfault.ErrInvalidDirection},
+	}
+
+	for _, tc := range cases {
+		if _, err := blackholeRules("iptables", tc.blackhole); !errors.Is(err, tc.want) {
+			t.Fatalf("blackholeRules(%#v): expected %v, got %v", tc.blackhole, tc.want, err)
+		}
+	}
+}
+
+func TestDNSRedirectRules(t *testing.T) {
+	rules := dnsRedirectRules(40053)
+
+	want := [][]string{
+		{"-w", "-t", "nat", "-N", dnsRedirectChain},
+		{"-w", "-t", "nat", "-A", dnsRedirectChain, "-m", "mark", "--mark", "50384", "-j", "RETURN"},
+		{"-w", "-t", "nat", "-A", dnsRedirectChain, "-p", "udp", "--dport", "53", "-j", "REDIRECT", "--to-ports", "40053"},
+		{"-w", "-t", "nat", "-I", "OUTPUT", "1", "-j", dnsRedirectChain},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("expected %v, got %v", want, rules)
+	}
+}
+
+func TestHTTPRedirectRules(t *testing.T) {
+	rules := httpRedirectRules([]portRedirect{{port: 8080, proxyPort: 41000}})
+
+	want := [][]string{
+		{"-w", "-t", "nat", "-N", httpRedirectChain},
+		{"-w", "-t", "nat", "-A", httpRedirectChain, "-p", "tcp", "--dport", "8080", "-j", "REDIRECT", "--to-ports", "41000"},
+		{"-w", "-t", "nat", "-I", "PREROUTING", "1", "-j", httpRedirectChain},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("expected %v, got %v", want, rules)
+	}
+}
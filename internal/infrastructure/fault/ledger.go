@@ -0,0 +1,111 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stateDir holds what injectors record on the host so that a panic rollback in a later
+// chaos-dock process can undo their faults. The records decide which paths a revert deletes
+// and which networks it reconnects, so the directory is created for the current user only.
+const stateDir = "/var/lib/chaos-dock"
+
+// ledger records, per container, a JSON list of entries in a file under dir. An entry replaces
+// a recorded entry with the same key.
+type ledger[T any] struct {
+	dir string
+	key func(T) string
+}
+
+func newLedger[T any](name string, key func(T) string) ledger[T] {
+	return ledger[T]{dir: filepath.Join(stateDir, name), key: key}
+}
+
+func (l ledger[T]) record(containerID string, entry T) error {
+	entries, err := l.entries(containerID)
+	if err != nil {
+		return err
+	}
+	return l.write(containerID, append(l.without(entries, l.key(entry)), entry))
+}
+
+func (l ledger[T]) entries(containerID string) ([]T, error) {
+	if err := checkStateDir(l.dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	f, err := openStateFile(l.path(containerID), os.O_RDONLY, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read ledger: %w", err)
+	}
+	defer f.Close()
+
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read ledger: %w", err)
+	}
+	var entries []T
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("decode ledger %s: %w", l.path(containerID), err)
+	}
+	return entries, nil
+}
+
+func (l ledger[T]) forget(containerID string, key string) error {
+	entries, err := l.entries(containerID)
+	if err != nil {
+		return err
+	}
+	return l.write(containerID, l.without(entries, key))
+}
+
+func (l ledger[T]) without(entries []T, key string) []T {
+	kept := entries[:0]
+	for _, existing := range entries {
+		if l.key(existing) != key {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
+
+func (l ledger[T]) write(containerID string, entries []T) error {
+	if len(entries) == 0 {
+		if err := os.Remove(l.path(containerID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encode ledger: %w", err)
+	}
+	if err := prepareStateDir(l.dir); err != nil {
+		return err
+	}
+	f, err := openStateFile(l.path(containerID), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("write ledger: %w", err)
+	}
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return fmt.Errorf("write ledger: %w", err)
+	}
+	return f.Close()
+}
+
+func (l ledger[T]) path(containerID string) string {
+	return filepath.Join(l.dir, strings.NewReplacer("/", "_", string(os.PathSeparator), "_").Replace(containerID))
+}
@@ -0,0 +1,100 @@
+package fault
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	memoryHogHelper = "memory-hog"
+	memoryHogChunk  = 16 * 1024 * 1024
+	pageSize        = 4096
+	// oomOvershootDivisor sets how far past memory plus swap the OOM mode allocates, as a
+	// fraction of the memory limit.
+	oomOvershootDivisor = 2
+)
+
+func memoryHogArgs(bytes uint64, duration time.Duration) []string {
+	return helperArgs(memoryHogHelper,
+		"-bytes", fmt.Sprint(bytes),
+		"-duration", duration.String(),
+	)
+}
+
+func runMemoryHog(args []string) error {
+	flags := flag.NewFlagSet(memoryHogHelper, flag.ContinueOnError)
+	bytes := flags.Uint64("bytes", 0, "bytes to allocate and touch")
+	duration := flags.Duration("duration", 0, "how long to hold the allocation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *bytes == 0 || *duration <= 0 {
+		return fmt.Errorf("invalid memory hog parameters")
+	}
+
+	deadline := time.Now().Add(*duration)
+	held := allocate(*bytes)
+
+	time.Sleep(time.Until(deadline))
+	runtime.KeepAlive(held)
+	return nil
+}
+
+// allocate allocates bytes in chunks and writes to every page, so the memory is resident and
+// charged to the cgroup rather than only reserved.
+func allocate(bytes uint64) [][]byte {
+	var held [][]byte
+	for remaining := bytes; remaining > 0; {
+		size := uint64(memoryHogChunk)
+		if remaining < size {
+			size = remaining
+		}
+		chunk := make([]byte, size)
+		for i := 0; i < len(chunk); i += pageSize {
+			chunk[i] = 1
+		}
+		held = append(held, chunk)
+		remaining -= size
+	}
+	return held
+}
+
+// memoryStressBytes resolves how much a memory stress allocates for a container whose limit
+// is limit and that may use swap bytes of swap on top; a zero limit means the container is
+// unlimited. The OOM mode has to fill the swap allowance too before the limit is crossed.
+func memoryStressBytes(stress domainfault.MemoryStress, limit uint64, swap uint64) (uint64, error) {
+	switch {
+	case stress.OOM:
+		if limit == 0 {
+			// Crossing an absent limit would exhaust the host instead of the container.
+			return 0, domainfault.ErrMemoryLimitUnavailable
+		}
+		return limit + swap + limit/oomOvershootDivisor, nil
+	case stress.LimitPercent > 0:
+		if limit == 0 {
+			return 0, domainfault.ErrMemoryLimitUnavailable
+		}
+		return uint64(float64(limit) * stress.LimitPercent / 100), nil
+	case stress.Bytes > 0:
+		return stress.Bytes, nil
+	default:
+		return 0, domainfault.ErrInvalidSize
+	}
+}
+
+func validateMemoryStress(stress domainfault.MemoryStress) error {
+	if stress.LimitPercent < 0 || stress.LimitPercent > 100 {
+		return domainfault.ErrInvalidPercentage
+	}
+	if !stress.OOM && stress.Bytes == 0 && stress.LimitPercent == 0 {
+		return domainfault.ErrInvalidSize
+	}
+	if stress.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestMemoryStressBytes(t *testing.T) {
+	const limit = 512 * 1024 * 1024
+
+	cases := []struct {
+		name   string
+		stress domainfault.MemoryStress
+		want   uint64
+	}{
+		{"absolute", domainfault.MemoryStress{Bytes: 64 * 1024 * 1024}, 64 * 1024 * 1024},
+		{"percent", domainfault.MemoryStress{LimitPercent: 75}, 384 * 1024 * 1024},
+		{"oom", domainfault.MemoryStress{OOM: true}, limit + limit/2},
+	}
+	for _, tc := range cases {
+		got, err := memoryStressBytes(tc.stress, limit, 0)
+		if err != nil {
+			t.Fatalf("%s: memoryStressBytes returned error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %d bytes, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestMemoryStressBytes_OOMFillsSwap(t *testing.T) {
+	const limit = 512 * 1024 * 1024
+
+	got, err := memoryStressBytes(domainfault.MemoryStress{OOM: true}, limit, limit)
+	if err != nil {
+		t.Fatalf("memoryStressBytes returned error: %v", err)
+	}
+	if got <= 2*limit {
+		t.Fatalf("expected the allocation to exceed memory plus swap (%d), got %d", 2*limit, got)
+	}
+}
+
+func TestMemoryStressBytes_RequiresLimit(t *testing.T) {
+	for _, stress := range []domainfault.MemoryStress{{LimitPercent: 50}, {OOM: true}} {
+		if _, err := memoryStressBytes(stress, 0, 0); !errors.Is(err, domainfault.ErrMemoryLimitUnavailable) {
+			t.Fatalf("expected ErrMemoryLimitUnavailable for %#v, got %v", stress, err)
+		}
+	}
+}
+
+func TestRunHelper_MemoryHog(t *testing.T) {
+	args := append([]string{"chaos-dock"}, memoryHogArgs(1024*1024, 10*time.Millisecond)...)
+	if err := RunHelper(args); err != nil {
+		t.Fatalf("RunHelper returned error: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// MemoryStressInjector runs a memory hog helper inside the target container's cgroup, so the
+// allocation counts against the container's memory limit.
+type MemoryStressInjector struct {
+	pidResolver PIDResolver
+}
+
+func NewMemoryStressInjector(pidResolver PIDResolver) *MemoryStressInjector {
+	return &MemoryStressInjector{pidResolver: pidResolver}
+}
+
+func (m *MemoryStressInjector) StressMemory(ctx context.Context, containerID string, stress domainfault.MemoryStress) error {
+	if err := validateMemoryStress(stress); err != nil {
+		return err
+	}
+
+	dir, err := m.cgroupDir(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	limit, err := memoryLimit(dir)
+	if err != nil && !errors.Is(err, domainfault.ErrMemoryLimitUnavailable) {
+		return fmt.Errorf("read memory limit of container %q: %w", strings.TrimSpace(containerID), err)
+	}
+	var swap uint64
+	if stress.OOM && limit > 0 {
+		swap, err = swapLimit(dir, limit)
+		if err != nil {
+			return fmt.Errorf("read swap limit of container %q: %w", strings.TrimSpace(containerID), err)
+		}
+	}
+	bytes, err := memoryStressBytes(stress, limit, swap)
+	if err != nil {
+		return fmt.Errorf("stress memory in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+
+	cmd, err := startHelper(dir, memoryHogArgs(bytes, stress.Duration))
+	if err != nil {
+		return fmt.Errorf("stress memory in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+
+	// In OOM mode the helper is expected to be killed by the OOM killer, which waitHelper
+	// treats like a revert.
+	if err := waitHelper(ctx, cmd); err != nil {
+		return fmt.Errorf("stress memory in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+	return nil
+}
+
+func (m *MemoryStressInjector) RevertMemoryStress(ctx context.Context, containerID string) error {
+	dir, err := m.cgroupDir(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	if err := killHelpers(dir, memoryHogHelper); err != nil {
+		return fmt.Errorf("revert memory stress in container %q: %w", strings.TrimSpace(containerID), err)
+	}
+	return nil
+}
+
+func (m *MemoryStressInjector) cgroupDir(ctx context.Context, containerID string) (string, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return "", domainfault.ErrInvalidContainerID
+	}
+	if m.pidResolver == nil {
+		return "", fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := m.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	dir, err := containerCgroupDir(pid, "memory")
+	if err != nil {
+		return "", fmt.Errorf("locate cgroup of container %q: %w", containerID, err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type MemoryStressInjector struct{}
+
+func NewMemoryStressInjector(_ PIDResolver) *MemoryStressInjector {
+	return &MemoryStressInjector{}
+}
+
+func (m *MemoryStressInjector) StressMemory(ctx context.Context, containerID string, stress domainfault.MemoryStress) error {
+	_ = ctx
+	_ = containerID
+	_ = stress
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (m *MemoryStressInjector) RevertMemoryStress(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,152 @@
+package fault
+
+import (
+	"strconv"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	// defaultTBFBurst comfortably exceeds rate/HZ for the rates used in local experiments.
+	defaultTBFBurst = 32 * 1024
+	// defaultTBFLatency bounds queueing when no explicit limit is configured.
+	defaultTBFLatency = "400ms"
+)
+
+var supportedDistributions = map[string]struct{}{
+	"normal":       {},
+	"pareto":       {},
+	"paretonormal": {},
+}
+
+// netemArgs renders every configured impairment into the option list of one netem qdisc,
+// so combined faults do not overwrite each other.
+func netemArgs(netem domainfault.Netem) ([]string, error) {
+	var args []string
+
+	if netem.Latency != nil {
+		delay, err := delayArgs(*netem.Latency)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, delay...)
+	}
+
+	impairments := []struct {
+		keyword     string
+		probability *domainfault.Probability
+	}{
+		{"loss", netem.Loss},
+		{"corrupt", netem.Corrupt},
+		{"duplicate", netem.Duplicate},
+		{"reorder", netem.Reorder},
+	}
+	for _, impairment := range impairments {
+		if impairment.probability == nil {
+			continue
+		}
+		rendered, err := probabilityArgs(impairment.keyword, *impairment.probability)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, rendered...)
+	}
+
+	if netem.Rate > 0 {
+		args = append(args, "rate", formatRate(netem.Rate))
+	}
+
+	if netem.Reorder != nil && netem.Latency == nil {
+		// netem only reorders packets that are held back by a delay.
+		return nil, domainfault.ErrReorderRequiresDelay
+	}
+	if len(args) == 0 {
+		return nil, domainfault.ErrEmptyNetem
+	}
+
+	return args, nil
+}
+
+// probabilityArgs renders KEYWORD PERCENT [CORRELATION].
+func probabilityArgs(keyword string, p domainfault.Probability) ([]string, error) {
+	if p.Percent <= 0 || p.Percent > 100 || p.Correlation < 0 || p.Correlation > 100 {
+		return nil, domainfault.ErrInvalidPercentage
+	}
+
+	args := []string{keyword, formatPercent(p.Percent)}
+	if p.Correlation > 0 {
+		args = append(args, formatPercent(p.Correlation))
+	}
+	return args, nil
+}
+
+// delayArgs renders a latency into netem arguments:
+// delay TIME [JITTER [CORRELATION]] [distribution {normal|pareto|paretonormal}].
+func delayArgs(latency domainfault.Latency) ([]string, error) {
+	if latency.Delay <= 0 {
+		return nil, domainfault.ErrInvalidLatencyDuration
+	}
+	if latency.Jitter < 0 {
+		return nil, domainfault.ErrInvalidJitterDuration
+	}
+	if latency.Correlation < 0 || latency.Correlation > 100 {
+		return nil, domainfault.ErrInvalidPercentage
+	}
+
+	distribution := strings.ToLower(strings.TrimSpace(latency.Distribution))
+	if distribution != "" {
+		if _, ok := supportedDistributions[distribution]; !ok {
+			return nil, domainfault.ErrInvalidDistribution
+		}
+	}
+
+	args := []string{"delay", latency.Delay.String()}
+	if latency.Jitter == 0 {
+		// netem ignores correlation and distribution without jitter.
+		return args, nil
+	}
+
+	args = append(args, latency.Jitter.String())
+	if latency.Correlation > 0 {
+		args = append(args, formatPercent(latency.Correlation))
+	}
+	if distribution != "" {
+		args = append(args, "distribution", distribution)
+	}
+
+	return args, nil
+}
+
+// tbfArgs renders a bandwidth limit into tbf arguments: tbf rate RATE burst BYTES (limit BYTES | latency TIME).
+func tbfArgs(bandwidth domainfault.Bandwidth) ([]string, error) {
+	if bandwidth.Rate == 0 {
+		return nil, domainfault.ErrInvalidBandwidthRate
+	}
+
+	burst := bandwidth.Burst
+	if burst == 0 {
+		burst = defaultTBFBurst
+	}
+
+	args := []string{"tbf", "rate", formatRate(bandwidth.Rate), "burst", formatSize(burst)}
+	if bandwidth.Limit > 0 {
+		args = append(args, "limit", formatSize(bandwidth.Limit))
+	} else {
+		args = append(args, "latency", defaultTBFLatency)
+	}
+
+	return args, nil
+}
+
+func formatRate(bitsPerSecond uint64) string {
+	return strconv.FormatUint(bitsPerSecond, 10) + "bit"
+}
+
+func formatSize(bytes uint64) string {
+	return strconv.FormatUint(bytes, 10) + "b"
+}
+
+func formatPercent(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,145 @@
+package fault
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestDelayArgs_FixedDelay(t *testing.T) {
+	args, err := delayArgs(domainfault.Latency{Delay: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("delayArgs returned error: %v", err)
+	}
+
+	want := []string{"delay", "500ms"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestDelayArgs_JitterCorrelationDistribution(t *testing.T) {
+	args, err := delayArgs(domainfault.Latency{
+		Delay:        100 * time.Millisecond,
+		Jitter:       20 * time.Millisecond,
+		Correlation:  25,
+		Distribution: "Pareto",
+	})
+	if err != nil {
+		t.Fatalf("delayArgs returned error: %v", err)
+	}
+
+	want := []string{"delay", "100ms", "20ms", "25%", "distribution", "pareto"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestDelayArgs_InvalidDistribution(t *testing.T) {
+	_, err := delayArgs(domainfault.Latency{
+		Delay:        100 * time.Millisecond,
+		Jitter:       20 * time.Millisecond,
+		Distribution: "uniformish",
+	})
+	if !errors.Is(err, domainfault.ErrInvalidDistribution) {
+		t.Fatalf("expected ErrInvalidDistribution, got %v", err)
+	}
+}
+
+func TestDelayArgs_InvalidDelay(t *testing.T) {
+	_, err := delayArgs(domainfault.Latency{})
+	if !errors.Is(err, domainfault.ErrInvalidLatencyDuration) {
+		t.Fatalf("expected ErrInvalidLatencyDuration, got %v", err)
+	}
+}
+
+func TestTBFArgs_Defaults(t *testing.T) {
+	args, err := tbfArgs(domainfault.Bandwidth{Rate: 256_000})
+	if err != nil {
+		t.Fatalf("tbfArgs returned error: %v", err)
+	}
+
+	want := []string{"tbf", "rate", "256000bit", "burst", "32768b", "latency", "400ms"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestTBFArgs_ExplicitBurstAndLimit(t *testing.T) {
+	args, err := tbfArgs(domainfault.Bandwidth{Rate: 1_000_000, Burst: 4096, Limit: 65536})
+	if err != nil {
+		t.Fatalf("tbfArgs returned error: %v", err)
+	}
+
+	want := []string{"tbf", "rate", "1000000bit", "burst", "4096b", "limit", "65536b"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestTBFArgs_RequiresRate(t *testing.T) {
+	_, err := tbfArgs(domainfault.Bandwidth{})
+	if !errors.Is(err, domainfault.ErrInvalidBandwidthRate) {
+		t.Fatalf("expected ErrInvalidBandwidthRate, got %v", err)
+	}
+}
+
+func TestNetemArgs_ReorderWithDelay(t *testing.T) {
+	args, err := netemArgs(domainfault.Netem{
+		Latency: &domainfault.Latency{Delay: 10 * time.Millisecond},
+		Corrupt: &domainfault.Probability{Percent: 0.1},
+		Reorder: &domainfault.Probability{Percent: 25, Correlation: 50},
+	})
+	if err != nil {
+		t.Fatalf("netemArgs returned error: %v", err)
+	}
+
+	want := []string{"delay", "10ms", "corrupt", "0.1%", "reorder", "25%", "50%"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
+
+func TestNetemArgs_ReorderRequiresDelay(t *testing.T) {
+	_, err := netemArgs(domainfault.Netem{
+		Reorder: &domainfault.Probability{Percent: 25},
+	})
+	if !errors.Is(err, domainfault.ErrReorderRequiresDelay) {
+		t.Fatalf("expected ErrReorderRequiresDelay, got %v", err)
+	}
+}
+
+func TestNetemArgs_Empty(t *testing.T) {
+	_, err := netemArgs(domainfault.Netem{})
+	if !errors.Is(err, domainfault.ErrEmptyNetem) {
+		t.Fatalf("expected ErrEmptyNetem, got %v", err)
+	}
+}
+
+func TestNetemArgs_InvalidPercentage(t *testing.T) {
+	_, err := netemArgs(domainfault.Netem{
+		Duplicate: &domainfault.Probability{Percent: 0},
+	})
+	if !errors.Is(err, domainfault.ErrInvalidPercentage) {
+		t.Fatalf("expected ErrInvalidPercentage, got %v", err)
+	}
+}
+
+func TestNetemArgs_CompositeWithRate(t *testing.T) {
+	args, err := netemArgs(domainfault.Netem{
+		Latency: &domainfault.Latency{Delay: 100 * time.Millisecond, Jitter: 20 * time.Millisecond},
+		Loss:    &domainfault.Probability{Percent: 5},
+		Rate:    1_000_000,
+	})
+	if err != nil {
+		t.Fatalf("netemArgs returned error: %v", err)
+	}
+
+	want := []string{"delay", "100ms", "20ms", "loss", "5%", "rate", "1000000bit"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("expected %v, got %v", want, args)
+	}
+}
@@ -0,0 +1,66 @@
+//go:build linux
+
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// inNetworkNamespace runs fn on an OS thread that has joined the network namespace of pid.
+// Sockets opened by fn stay bound to that namespace after the thread switches back.
+func inNetworkNamespace(pid int, fn func() error) error {
+	errCh := make(chan error, 1)
+
+	// A dedicated goroutine keeps the namespace switch off the caller's thread. If switching
+	// back fails the thread stays locked and the runtime discards it when the goroutine exits.
+	go func() {
+		runtime.LockOSThread()
+
+		origin, err := os.Open("/proc/thread-self/ns/net")
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- fmt.Errorf("%w: open host network namespace: %v", domainfault.ErrNetworkNamespaceUnavailable, err)
+			return
+		}
+		defer origin.Close()
+
+		target, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- namespaceError(err)
+			return
+		}
+		defer target.Close()
+
+		if err := unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			errCh <- namespaceError(err)
+			return
+		}
+
+		fnErr := fn()
+
+		if err := unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET); err != nil {
+			errCh <- fmt.Errorf("restore host network namespace: %w", err)
+			return
+		}
+		runtime.UnlockOSThread()
+		errCh <- fnErr
+	}()
+
+	return <-errCh
+}
+
+func namespaceError(err error) error {
+	if errors.Is(err, os.ErrPermission) || errors.Is(err, unix.EPERM) {
+		return fmt.Errorf("%w: %v", domainfault.ErrInsufficientPrivileges, err)
+	}
+	return fmt.Errorf("%w: %v", domainfault.ErrNetworkNamespaceUnavailable, err)
+}
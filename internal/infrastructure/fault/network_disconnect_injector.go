@@ -0,0 +1,122 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type networkAttacher interface {
+	NetworkEndpoint(ctx context.Context, containerID string, network string) (domainfault.NetworkEndpoint, error)
+	DisconnectNetwork(ctx context.Context, containerID string, network string) error
+	ConnectNetwork(ctx context.Context, containerID string, endpoint domainfault.NetworkEndpoint) error
+}
+
+// NetworkDisconnectInjector partitions a container at the Docker network level, so it needs
+// neither nsenter nor tools inside the image.
+type NetworkDisconnectInjector struct {
+	attacher networkAttacher
+	ledger   ledger[domainfault.NetworkEndpoint]
+}
+
+func NewNetworkDisconnectInjector(attacher networkAttacher) *NetworkDisconnectInjector {
+	return &NetworkDisconnectInjector{attacher: attacher, ledger: newEndpointLedger()}
+}
+
+// DisconnectNetwork detaches the container from the network for the fault duration and then
+// reconnects it with its original aliases and addresses. The reconnect runs on a context
+// detached from ctx, so a cancelled run still restores the attachment.
+func (n *NetworkDisconnectInjector) DisconnectNetwork(ctx context.Context, containerID string, fault domainfault.NetworkDisconnect) (err error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if n.attacher == nil {
+		return fmt.Errorf("network attacher is required")
+	}
+	if strings.TrimSpace(fault.Network) == "" {
+		return fmt.Errorf("network name is required")
+	}
+	if fault.Duration <= 0 {
+		return fmt.Errorf("disconnect duration must be greater than zero")
+	}
+
+	endpoint, err := n.attacher.NetworkEndpoint(ctx, containerID, fault.Network)
+	if err != nil {
+		return err
+	}
+	if err := n.ledger.record(containerID, endpoint); err != nil {
+		return err
+	}
+
+	if err := n.attacher.DisconnectNetwork(ctx, containerID, endpoint.Network); err != nil {
+		if forgetErr := n.ledger.forget(containerID, endpoint.Network); forgetErr != nil {
+			return errors.Join(fmt.Errorf("%w: %v", domainfault.ErrNetworkDisconnectFailed, err), forgetErr)
+		}
+		return fmt.Errorf("%w: %v", domainfault.ErrNetworkDisconnectFailed, err)
+	}
+	defer func() {
+		if reconnectErr := n.reconnect(context.WithoutCancel(ctx), containerID, endpoint); reconnectErr != nil && err == nil {
+			err = reconnectErr
+		}
+	}()
+
+	return sleepContext(ctx, fault.Duration)
+}
+
+// RevertNetworkDisconnect reconnects the container to every network recorded for it,
+// including disconnects made by another chaos-dock process.
+func (n *NetworkDisconnectInjector) RevertNetworkDisconnect(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if n.attacher == nil {
+		return fmt.Errorf("network attacher is required")
+	}
+
+	endpoints, err := n.ledger.entries(containerID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, endpoint := range endpoints {
+		if _, err := n.attacher.NetworkEndpoint(ctx, containerID, endpoint.Network); err == nil {
+			// Already attached again, e.g. by the injector that recorded it.
+			if err := n.ledger.forget(containerID, endpoint.Network); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := n.reconnect(ctx, containerID, endpoint); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reconnect attaches the endpoint again. Addresses that IPAM assigned can only be requested
+// on networks with a user-configured subnet, so if that fails the container is reconnected
+// with a fresh address rather than left detached.
+func (n *NetworkDisconnectInjector) reconnect(ctx context.Context, containerID string, endpoint domainfault.NetworkEndpoint) error {
+	err := n.attacher.ConnectNetwork(ctx, containerID, endpoint)
+	if err != nil && !endpoint.StaticIP && (endpoint.IPv4Address != "" || endpoint.IPv6Address != "") {
+		endpoint.IPv4Address, endpoint.IPv6Address = "", ""
+		err = n.attacher.ConnectNetwork(ctx, containerID, endpoint)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", domainfault.ErrNetworkReconnectFailed, err)
+	}
+
+	return n.ledger.forget(containerID, endpoint.Network)
+}
+
+// newEndpointLedger records, per container, the network attachments that were removed.
+func newEndpointLedger() ledger[domainfault.NetworkEndpoint] {
+	return newLedger("network-disconnect", func(endpoint domainfault.NetworkEndpoint) string { return endpoint.Network })
+}
@@ -0,0 +1,107 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func testEndpointLedger(t *testing.T) ledger[domainfault.NetworkEndpoint] {
+	l := newEndpointLedger()
+	l.dir = filepath.Join(t.TempDir(), "network-disconnect")
+	return l
+}
+
+type mockNetworkAttacher struct {
+	attached   map[string]domainfault.NetworkEndpoint
+	connected  []domainfault.NetworkEndpoint
+	rejectIPAM bool
+}
+
+func (m *mockNetworkAttacher) NetworkEndpoint(_ context.Context, _ string, network string) (domainfault.NetworkEndpoint, error) {
+	for name, endpoint := range m.attached {
+		if name == network || name == "shop_"+network {
+			return endpoint, nil
+		}
+	}
+	return domainfault.NetworkEndpoint{}, domainfault.ErrNetworkNotAttached
+}
+
+func (m *mockNetworkAttacher) DisconnectNetwork(_ context.Context, _ string, network string) error {
+	delete(m.attached, network)
+	return nil
+}
+
+func (m *mockNetworkAttacher) ConnectNetwork(ctx context.Context, _ string, endpoint domainfault.NetworkEndpoint) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if m.rejectIPAM && endpoint.IPv4Address != "" {
+		return errors.New("user specified IP address is supported only when connecting to networks with user configured subnets")
+	}
+	m.connected = append(m.connected, endpoint)
+	m.attached[endpoint.Network] = endpoint
+	return nil
+}
+
+func TestNetworkDisconnectInjector_ReconnectsWithOriginalSettings(t *testing.T) {
+	original := domainfault.NetworkEndpoint{
+		Network:     "shop_backend",
+		Aliases:     []string{"db", "postgres"},
+		IPv4Address: "172.20.0.5",
+		StaticIP:    true,
+	}
+	attacher := &mockNetworkAttacher{attached: map[string]domainfault.NetworkEndpoint{"shop_backend": original}}
+	injector := &NetworkDisconnectInjector{attacher: attacher, ledger: testEndpointLedger(t)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := injector.DisconnectNetwork(ctx, "db", domainfault.NetworkDisconnect{Network: "backend", Duration: time.Hour})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(attacher.connected) != 1 || !reflect.DeepEqual(attacher.connected[0], original) {
+		t.Fatalf("expected reconnect with %#v, got %#v", original, attacher.connected)
+	}
+
+	endpoints, err := injector.ledger.entries("db")
+	if err != nil || len(endpoints) != 0 {
+		t.Fatalf("expected empty ledger after reconnect, got %#v (%v)", endpoints, err)
+	}
+}
+
+func TestNetworkDisconnectInjector_FallsBackToFreshAddress(t *testing.T) {
+	attacher := &mockNetworkAttacher{
+		attached:   map[string]domainfault.NetworkEndpoint{},
+		rejectIPAM: true,
+	}
+	injector := &NetworkDisconnectInjector{attacher: attacher, ledger: testEndpointLedger(t)}
+
+	endpoint := domainfault.NetworkEndpoint{Network: "shop_backend", Aliases: []string{"db"}, IPv4Address: "172.20.0.5"}
+	if err := injector.ledger.record("db", endpoint); err != nil {
+		t.Fatalf("record returned error: %v", err)
+	}
+
+	if err := injector.RevertNetworkDisconnect(context.Background(), "db"); err != nil {
+		t.Fatalf("RevertNetworkDisconnect returned error: %v", err)
+	}
+	if len(attacher.connected) != 1 || attacher.connected[0].IPv4Address != "" || attacher.connected[0].Aliases[0] != "db" {
+		t.Fatalf("expected reconnect without the dynamic address, got %#v", attacher.connected)
+	}
+}
+
+func TestNetworkDisconnectInjector_UnknownNetwork(t *testing.T) {
+	attacher := &mockNetworkAttacher{attached: map[string]domainfault.NetworkEndpoint{}}
+	injector := &NetworkDisconnectInjector{attacher: attacher, ledger: testEndpointLedger(t)}
+
+	err := injector.DisconnectNetwork(context.Background(), "db", domainfault.NetworkDisconnect{Network: "frontend", Duration: time.Second})
+	if !errors.Is(err, domainfault.ErrNetworkNotAttached) {
+		t.Fatalf("expected ErrNetworkNotAttached, got %v", err)
+	}
+}
@@ -3,142 +3,276 @@
 package fault
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"os/exec"
-	"strconv"
 	"strings"
-	"time"
 
 	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
 )
 
-const (
-	defaultInterfaceName = "eth0"
-	defaultNsenterBinary = "nsenter"
-	defaultCommandTTL    = 10 * time.Second
-)
-
-type PIDResolver interface {
-	ContainerPID(ctx context.Context, containerID string) (int, error)
-}
+const defaultInterfaceName = "eth0"
 
 type NetworkLatencyInjector struct {
-	pidResolver    PIDResolver
-	interfaceName  string
-	nsenterBinary  string
-	commandTimeout time.Duration
+	pidResolver     PIDResolver
+	addressResolver AddressResolver
+	interfaceName   string
+	nsenter         nsenterRunner
 }
 
-func NewNetworkLatencyInjector(pidResolver PIDResolver) *NetworkLatencyInjector {
+func NewNetworkLatencyInjector(pidResolver PIDResolver, addressResolver AddressResolver) *NetworkLatencyInjector {
 	return &NetworkLatencyInjector{
-		pidResolver:    pidResolver,
-		interfaceName:  defaultInterfaceName,
-		nsenterBinary:  defaultNsenterBinary,
-		commandTimeout: defaultCommandTTL,
+		pidResolver:     pidResolver,
+		addressResolver: addressResolver,
+		interfaceName:   defaultInterfaceName,
+		nsenter:         newNsenterRunner(),
 	}
 }
 
-func (n *NetworkLatencyInjector) InjectNetworkLatency(ctx context.Context, containerID string, delay time.Duration) error {
-	containerID = strings.TrimSpace(containerID)
-	if containerID == "" {
-		return domainfault.ErrInvalidContainerID
+func (n *NetworkLatencyInjector) InjectNetworkLatency(ctx context.Context, containerID string, latency domainfault.Latency) error {
+	args, err := netemArgs(domainfault.Netem{Latency: &latency})
+	if err != nil {
+		return err
 	}
-	if delay <= 0 {
-		return domainfault.ErrInvalidLatencyDuration
+
+	if err := n.replaceNetem(ctx, containerID, "", args); err != nil {
+		return fmt.Errorf("inject latency into container %q: %w", strings.TrimSpace(containerID), err)
 	}
-	if n.pidResolver == nil {
-		return fmt.Errorf("pid resolver is required")
+
+	return nil
+}
+
+func (n *NetworkLatencyInjector) InjectPacketLoss(ctx context.Context, containerID string, loss domainfault.Probability) error {
+	args, err := netemArgs(domainfault.Netem{Loss: &loss})
+	if err != nil {
+		return err
 	}
 
-	pid, err := n.pidResolver.ContainerPID(ctx, containerID)
+	if err := n.replaceNetem(ctx, containerID, "", args); err != nil {
+		return fmt.Errorf("inject packet loss into container %q: %w", strings.TrimSpace(containerID), err)
+	}
+
+	return nil
+}
+
+// InjectNetem applies several netem impairments, such as reordering on top of a delay,
+// through one root qdisc. With a destination only matching egress traffic is impaired.
+func (n *NetworkLatencyInjector) InjectNetem(ctx context.Context, containerID string, netem domainfault.Netem) error {
+	args, err := netemArgs(netem)
 	if err != nil {
-		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+		return err
 	}
 
-	args := []string{"qdisc", "replace", "dev", n.interfaceName, "root", "netem", "delay", delay.String()}
-	if err := n.runTC(ctx, pid, args); err != nil {
-		return fmt.Errorf("inject latency into container %q: %w", containerID, err)
+	if netem.To != nil {
+		if err := n.replaceScopedNetem(ctx, containerID, netem.Direction, *netem.To, args); err != nil {
+			return fmt.Errorf("inject scoped netem impairments into container %q: %w", strings.TrimSpace(containerID), err)
+		}
+		return nil
+	}
+
+	if err := n.replaceNetem(ctx, containerID, netem.Direction, args); err != nil {
+		return fmt.Errorf("inject netem impairments into container %q: %w", strings.TrimSpace(containerID), err)
 	}
 
 	return nil
 }
 
-func (n *NetworkLatencyInjector) RevertNetworkLatency(ctx context.Context, containerID string) error {
-	containerID = strings.TrimSpace(containerID)
-	if containerID == "" {
-		return domainfault.ErrInvalidContainerID
+// InjectBandwidthLimit replaces the root qdisc with a token bucket filter. It shares the root
+// handle with netem, so RevertNetworkLatency removes it as well.
+func (n *NetworkLatencyInjector) InjectBandwidthLimit(ctx context.Context, containerID string, bandwidth domainfault.Bandwidth) error {
+	tbf, err := tbfArgs(bandwidth)
+	if err != nil {
+		return err
 	}
-	if n.pidResolver == nil {
-		return fmt.Errorf("pid resolver is required")
+
+	if err := n.replaceRootQDisc(ctx, containerID, bandwidth.Direction, tbf); err != nil {
+		return fmt.Errorf("inject bandwidth limit into container %q: %w", strings.TrimSpace(containerID), err)
 	}
 
-	pid, err := n.pidResolver.ContainerPID(ctx, containerID)
+	return nil
+}
+
+// replaceNetem installs a root netem qdisc with the given options.
+func (n *NetworkLatencyInjector) replaceNetem(ctx context.Context, containerID string, direction string, netemArgs []string) error {
+	return n.replaceRootQDisc(ctx, containerID, direction, append([]string{"netem"}, netemArgs...))
+}
+
+// replaceRootQDisc swaps the root qdisc of the target interface for egress and of the ifb
+// device for ingress. The side that is not shaped is cleared, so every shaping fault
+// overwrites the previous one and is undone by RevertNetworkLatency.
+func (n *NetworkLatencyInjector) replaceRootQDisc(ctx context.Context, containerID string, direction string, qdiscArgs []string) error {
+	egress, ingress, err := shapedDirections(direction)
 	if err != nil {
-		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+		return err
 	}
 
-	args := []string{"qdisc", "del", "dev", n.interfaceName, "root"}
-	err = n.runTC(ctx, pid, args)
-	if err != nil && !isMissingQDisc(err) {
-		return fmt.Errorf("revert latency in container %q: %w", containerID, err)
+	pid, err := n.containerPID(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	if ingress {
+		if err := n.redirectIngress(ctx, pid); err != nil {
+			return err
+		}
+		if err := n.runTC(ctx, pid, rootQDiscArgs(ifbInterfaceName, qdiscArgs)); err != nil {
+			return err
+		}
+	} else if err := n.removeIngressRedirect(ctx, pid); err != nil {
+		return err
+	}
+
+	if egress {
+		return n.runTC(ctx, pid, rootQDiscArgs(n.interfaceName, qdiscArgs))
+	}
+	return n.deleteRootQDisc(ctx, pid, n.interfaceName)
+}
+
+// replaceScopedNetem swaps the root qdisc for a prio qdisc whose extra band carries the
+// netem impairment, and steers traffic to the destination into that band with u32 filters.
+func (n *NetworkLatencyInjector) replaceScopedNetem(ctx context.Context, containerID string, direction string, to domainfault.Destination, netemArgs []string) error {
+	egress, ingress, err := shapedDirections(direction)
+	if err != nil {
+		return err
+	}
+	if ingress || !egress {
+		return domainfault.ErrDestinationEgressOnly
+	}
+
+	cidrs, err := resolveDestination(ctx, n.addressResolver, to)
+	if err != nil {
+		return err
+	}
+
+	pid, err := n.containerPID(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	if err := n.removeIngressRedirect(ctx, pid); err != nil {
+		return err
+	}
+	// Filters attached to an earlier prio root would survive a replace, so start from scratch.
+	if err := n.deleteRootQDisc(ctx, pid, n.interfaceName); err != nil {
+		return err
+	}
+	for _, args := range scopedNetemArgs(n.interfaceName, netemArgs, cidrs, to.Ports) {
+		if err := n.runTC(ctx, pid, args); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (n *NetworkLatencyInjector) runTC(ctx context.Context, pid int, tcArgs []string) error {
-	callCtx, cancel := context.WithTimeout(ctx, n.commandTimeout)
-	defer cancel()
+// redirectIngress creates the ifb device and mirrors all traffic arriving on the target
+// interface to it. An existing redirect is replaced.
+func (n *NetworkLatencyInjector) redirectIngress(ctx context.Context, pid int) error {
+	err := n.runIP(ctx, pid, domainfault.ErrIFBUnavailable, "link", "add", ifbInterfaceName, "type", "ifb")
+	if err != nil && !isExistingDevice(err) {
+		return err
+	}
+	if err := n.runIP(ctx, pid, domainfault.ErrIFBUnavailable, "link", "set", "dev", ifbInterfaceName, "up"); err != nil {
+		return err
+	}
 
-	nsenterArgs := []string{
-		"--target", strconv.Itoa(pid),
-		"--net",
-		"--mount",
-		"--",
-		"tc",
+	if err := n.removeIngressRedirect(ctx, pid); err != nil {
+		return err
+	}
+	if err := n.runTC(ctx, pid, []string{"qdisc", "add", "dev", n.interfaceName, "handle", "ffff:", "ingress"}); err != nil {
+		return err
 	}
-	nsenterArgs = append(nsenterArgs, tcArgs...)
+	return n.runTC(ctx, pid, ingressRedirectArgs(n.interfaceName, ifbInterfaceName))
+}
 
-	cmd := exec.CommandContext(callCtx, n.nsenterBinary, nsenterArgs...)
+// removeIngressRedirect deletes the ingress qdisc of the target interface together with its
+// redirect filter.
+func (n *NetworkLatencyInjector) removeIngressRedirect(ctx context.Context, pid int) error {
+	err := n.runTC(ctx, pid, []string{"qdisc", "del", "dev", n.interfaceName, "ingress"})
+	if err != nil && !isMissingQDisc(err) && !isMissingIngressQDisc(err) {
+		return err
+	}
+	return nil
+}
 
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+func (n *NetworkLatencyInjector) deleteRootQDisc(ctx context.Context, pid int, iface string) error {
+	err := n.runTC(ctx, pid, []string{"qdisc", "del", "dev", iface, "root"})
+	if err != nil && !isMissingQDisc(err) {
+		return err
+	}
+	return nil
+}
 
-	err := cmd.Run()
-	if err == nil {
-		return nil
+// RevertNetworkLatency removes the root qdisc, the ingress redirect and the ifb device.
+func (n *NetworkLatencyInjector) RevertNetworkLatency(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	pid, err := n.containerPID(ctx, containerID)
+	if err != nil {
+		return err
 	}
 
-	if errors.Is(callCtx.Err(), context.DeadlineExceeded) {
-		return fmt.Errorf("%w: %s", domainfault.ErrCommandTimeout, strings.TrimSpace(stderr.String()))
+	var errs []error
+	if err := n.deleteRootQDisc(ctx, pid, n.interfaceName); err != nil {
+		errs = append(errs, err)
+	}
+	if err := n.removeIngressRedirect(ctx, pid); err != nil {
+		errs = append(errs, err)
+	}
+	err = n.runIP(ctx, pid, domainfault.ErrTCCommandFailed, "link", "del", ifbInterfaceName)
+	if err != nil && !isMissingDevice(err) {
+		errs = append(errs, err)
 	}
 
-	if errors.Is(err, exec.ErrNotFound) {
-		return fmt.Errorf("%w: binary %q not found on host", domainfault.ErrNamespaceToolMissing, n.nsenterBinary)
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("revert latency in container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+func (n *NetworkLatencyInjector) containerPID(ctx context.Context, containerID string) (int, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return 0, domainfault.ErrInvalidContainerID
+	}
+	if n.pidResolver == nil {
+		return 0, fmt.Errorf("pid resolver is required")
 	}
 
-	combined := strings.ToLower(strings.TrimSpace(stderr.String() + " " + stdout.String() + " " + err.Error()))
-	switch {
-	case strings.Contains(combined, "tc: not found"),
-		strings.Contains(combined, "executable file not found"),
-		strings.Contains(combined, "command not found"):
-		// Sidecar Pattern: if the target image is distroless/scratch and lacks iproute2,
-		// a privileged helper container can join the same network namespace and run tc.
-		return fmt.Errorf("%w: container namespace does not expose tc/iproute2", domainfault.ErrIPRoute2Missing)
-	case strings.Contains(combined, "cannot open network namespace"),
-		strings.Contains(combined, "no such file or directory"):
-		return fmt.Errorf("%w: %s", domainfault.ErrNetworkNamespaceUnavailable, strings.TrimSpace(stderr.String()))
-	case strings.Contains(combined, "operation not permitted"),
-		strings.Contains(combined, "permission denied"):
-		return fmt.Errorf("%w: %s", domainfault.ErrInsufficientPrivileges, strings.TrimSpace(stderr.String()))
-	default:
-		return fmt.Errorf("%w: %v: %s", domainfault.ErrTCCommandFailed, err, strings.TrimSpace(stderr.String()))
+	pid, err := n.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return 0, fmt.Errorf("resolve pid for container %q: %w", containerID, err)
 	}
+	return pid, nil
+}
+
+func (n *NetworkLatencyInjector) runTC(ctx context.Context, pid int, tcArgs []string) error {
+	// Sidecar Pattern: if the target image is distroless/scratch and lacks iproute2,
+	// a privileged helper container can join the same network namespace and run tc.
+	_, err := n.nsenter.run(ctx, pid, namespaceCommand{
+		namespaces: []string{"--net", "--mount"},
+		binary:     "tc",
+		args:       tcArgs,
+		missingErr: domainfault.ErrIPRoute2Missing,
+		failedErr:  domainfault.ErrTCCommandFailed,
+	})
+	return err
+}
+
+func (n *NetworkLatencyInjector) runIP(ctx context.Context, pid int, failedErr error, ipArgs ...string) error {
+	_, err := n.nsenter.run(ctx, pid, namespaceCommand{
+		namespaces: []string{"--net", "--mount"},
+		binary:     "ip",
+		args:       ipArgs,
+		missingErr: domainfault.ErrIPRoute2Missing,
+		failedErr:  failedErr,
+	})
+	return err
+}
+
+func rootQDiscArgs(iface string, qdiscArgs []string) []string {
+	args := []string{"qdisc", "replace", "dev", iface, "root"}
+	return append(args, qdiscArgs...)
 }
 
 func isMissingQDisc(err error) bool {
@@ -146,5 +280,16 @@ func isMissingQDisc(err error) bool {
 		return false
 	}
 	raw := strings.ToLower(err.Error())
-	return strings.Contains(raw, "no such file") || strings.Contains(raw, "cannot find qdisc")
+	return strings.Contains(raw, "no such file") ||
+		strings.Contains(raw, "cannot find qdisc") ||
+		strings.Contains(raw, "cannot find specified qdisc")
+}
+
+// isMissingIngressQDisc matches older kernels, which reject deleting an absent ingress
+// qdisc as an invalid argument.
+func isMissingIngressQDisc(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "invalid argument")
 }
@@ -5,25 +5,41 @@ package fault
 import (
 	"context"
 	"fmt"
-	"time"
 
 	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
 )
 
-type PIDResolver interface {
-	ContainerPID(ctx context.Context, containerID string) (int, error)
-}
-
 type NetworkLatencyInjector struct{}
 
-func NewNetworkLatencyInjector(_ PIDResolver) *NetworkLatencyInjector {
+func NewNetworkLatencyInjector(_ PIDResolver, _ AddressResolver) *NetworkLatencyInjector {
 	return &NetworkLatencyInjector{}
 }
 
-func (n *NetworkLatencyInjector) InjectNetworkLatency(ctx context.Context, containerID string, delay time.Duration) error {
+func (n *NetworkLatencyInjector) InjectNetworkLatency(ctx context.Context, containerID string, latency domainfault.Latency) error {
+	_ = ctx
+	_ = containerID
+	_ = latency
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (n *NetworkLatencyInjector) InjectPacketLoss(ctx context.Context, containerID string, loss domainfault.Probability) error {
+	_ = ctx
+	_ = containerID
+	_ = loss
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (n *NetworkLatencyInjector) InjectBandwidthLimit(ctx context.Context, containerID string, bandwidth domainfault.Bandwidth) error {
+	_ = ctx
+	_ = containerID
+	_ = bandwidth
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (n *NetworkLatencyInjector) InjectNetem(ctx context.Context, containerID string, netem domainfault.Netem) error {
 	_ = ctx
 	_ = containerID
-	_ = delay
+	_ = netem
 	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
 }
 
@@ -0,0 +1,109 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// NetworkPartitionInjector installs drop rules for peer addresses inside the target's network namespace.
+type NetworkPartitionInjector struct {
+	pidResolver     PIDResolver
+	addressResolver AddressResolver
+	iptables        iptablesRunner
+}
+
+func NewNetworkPartitionInjector(pidResolver PIDResolver, addressResolver AddressResolver) *NetworkPartitionInjector {
+	return &NetworkPartitionInjector{
+		pidResolver:     pidResolver,
+		addressResolver: addressResolver,
+		iptables:        newIPTablesRunner(),
+	}
+}
+
+func (p *NetworkPartitionInjector) PartitionNetwork(ctx context.Context, containerID string, peers []string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	peers = normalizePeers(peers)
+	if len(peers) == 0 {
+		return domainfault.ErrNoPartitionPeers
+	}
+	if p.pidResolver == nil || p.addressResolver == nil {
+		return fmt.Errorf("pid and address resolvers are required")
+	}
+
+	var addresses []string
+	for _, peer := range peers {
+		ips, err := p.addressResolver.ContainerIPs(ctx, peer)
+		if err != nil {
+			return fmt.Errorf("resolve addresses for peer %q: %w", peer, err)
+		}
+		if len(ips) == 0 {
+			return fmt.Errorf("%w: %q", domainfault.ErrPeerAddressUnavailable, peer)
+		}
+		addresses = append(addresses, ips...)
+	}
+
+	pid, err := p.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	// Start from a clean chain so repeated runs do not stack duplicate rules.
+	if err := p.iptables.removeChains(ctx, pid, "filter", partitionChain); err != nil {
+		return fmt.Errorf("reset partition in container %q: %w", containerID, err)
+	}
+
+	for binary, familyAddresses := range splitByFamily(addresses) {
+		for _, rule := range partitionRules(familyAddresses) {
+			if err := p.iptables.run(ctx, pid, binary, rule); err != nil {
+				// Do not leave a half-built chain hooked into INPUT and OUTPUT.
+				if cleanupErr := p.iptables.removeChains(context.WithoutCancel(ctx), pid, "filter", partitionChain); cleanupErr != nil {
+					err = errors.Join(err, fmt.Errorf("remove partial partition: %w", cleanupErr))
+				}
+				return fmt.Errorf("partition container %q: %w", containerID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *NetworkPartitionInjector) RevertNetworkPartition(ctx context.Context, containerID string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+	if p.pidResolver == nil {
+		return fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := p.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	if err := p.iptables.removeChains(ctx, pid, "filter", partitionChain); err != nil {
+		return fmt.Errorf("revert partition in container %q: %w", containerID, err)
+	}
+
+	return nil
+}
+
+func normalizePeers(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, raw := range in {
+		peer := strings.TrimSpace(raw)
+		if peer != "" {
+			out = append(out, peer)
+		}
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type NetworkPartitionInjector struct{}
+
+func NewNetworkPartitionInjector(_ PIDResolver, _ AddressResolver) *NetworkPartitionInjector {
+	return &NetworkPartitionInjector{}
+}
+
+func (p *NetworkPartitionInjector) PartitionNetwork(ctx context.Context, containerID string, peers []string) error {
+	_ = ctx
+	_ = containerID
+	_ = peers
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (p *NetworkPartitionInjector) RevertNetworkPartition(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,90 @@
+//go:build linux
+
+package fault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	defaultNsenterBinary = "nsenter"
+	defaultCommandTTL    = 10 * time.Second
+)
+
+// namespaceCommand is a binary executed through nsenter inside the namespaces of a container process.
+type namespaceCommand struct {
+	namespaces []string // nsenter flags such as --net or --mount
+	binary     string
+	args       []string
+	missingErr error // reported when binary cannot be executed
+	failedErr  error // reported for any other failure of binary
+}
+
+type nsenterRunner struct {
+	binary  string
+	timeout time.Duration
+}
+
+func newNsenterRunner() nsenterRunner {
+	return nsenterRunner{
+		binary:  defaultNsenterBinary,
+		timeout: defaultCommandTTL,
+	}
+}
+
+// run executes command and returns its stdout. Failures are mapped onto the typed domain errors.
+func (r nsenterRunner) run(ctx context.Context, pid int, command namespaceCommand) (string, error) {
+	callCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	nsenterArgs := []string{"--target", strconv.Itoa(pid)}
+	nsenterArgs = append(nsenterArgs, command.namespaces...)
+	nsenterArgs = append(nsenterArgs, "--", command.binary)
+	nsenterArgs = append(nsenterArgs, command.args...)
+
+	cmd := exec.CommandContext(callCtx, r.binary, nsenterArgs...)
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return stdout.String(), nil
+	}
+
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%w: %s", domainfault.ErrCommandTimeout, strings.TrimSpace(stderr.String()))
+	}
+
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", fmt.Errorf("%w: binary %q not found on host", domainfault.ErrNamespaceToolMissing, r.binary)
+	}
+
+	combined := strings.ToLower(strings.TrimSpace(stderr.String() + " " + stdout.String() + " " + err.Error()))
+	switch {
+	case strings.Contains(combined, command.binary+": not found"),
+		strings.Contains(combined, "failed to execute "+command.binary),
+		strings.Contains(combined, "executable file not found"),
+		strings.Contains(combined, "command not found"):
+		return "", fmt.Errorf("%w: %q cannot be executed in the target namespace", command.missingErr, command.binary)
+	case strings.Contains(combined, "cannot open network namespace"),
+		strings.Contains(combined, "no such file or directory"):
+		return "", fmt.Errorf("%w: %s", domainfault.ErrNetworkNamespaceUnavailable, strings.TrimSpace(stderr.String()))
+	case strings.Contains(combined, "operation not permitted"),
+		strings.Contains(combined, "permission denied"):
+		return "", fmt.Errorf("%w: %s", domainfault.ErrInsufficientPrivileges, strings.TrimSpace(stderr.String()))
+	default:
+		return "", fmt.Errorf("%w: %v: %s", command.failedErr, err, strings.TrimSpace(stderr.String()))
+	}
+}
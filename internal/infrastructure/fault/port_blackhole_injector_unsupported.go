@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type PortBlackholeInjector struct{}
+
+func NewPortBlackholeInjector(_ PIDResolver) *PortBlackholeInjector {
+	return &PortBlackholeInjector{}
+}
+
+func (b *PortBlackholeInjector) BlackholePorts(ctx context.Context, containerID string, blackhole domainfault.PortBlackhole) error {
+	_ = ctx
+	_ = containerID
+	_ = blackhole
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (b *PortBlackholeInjector) RevertPortBlackhole(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,48 @@
+package fault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// processMatcher selects processes by their name (comm) and command line.
+type processMatcher struct {
+	name    string
+	cmdline *regexp.Regexp
+}
+
+func newProcessMatcher(kill domainfault.ProcessKill) (processMatcher, error) {
+	m := processMatcher{name: strings.TrimSpace(kill.Name)}
+	pattern := strings.TrimSpace(kill.Cmdline)
+	if m.name == "" && pattern == "" {
+		return processMatcher{}, fmt.Errorf("%w: a process name or command line pattern is required", domainfault.ErrInvalidProcessMatch)
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return processMatcher{}, fmt.Errorf("%w: cmdline %q: %v", domainfault.ErrInvalidProcessMatch, pattern, err)
+		}
+		m.cmdline = re
+	}
+	return m, nil
+}
+
+// matches reports whether a process matches, given the contents of /proc/<pid>/comm and
+// /proc/<pid>/cmdline.
+func (m processMatcher) matches(comm string, cmdline []byte) bool {
+	if m.name != "" && strings.TrimSpace(comm) != m.name {
+		return false
+	}
+	if m.cmdline != nil && !m.cmdline.MatchString(formatCmdline(cmdline)) {
+		return false
+	}
+	return true
+}
+
+// formatCmdline turns the NUL-separated arguments of /proc/<pid>/cmdline into a single line.
+func formatCmdline(raw []byte) string {
+	return strings.Join(strings.FieldsFunc(string(raw), func(r rune) bool { return r == 0 }), " ")
+}
@@ -0,0 +1,120 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ProcessKillInjector signals processes in a container's PID namespace from the host, so it
+// works for images without a shell or kill binary.
+type ProcessKillInjector struct {
+	pidResolver PIDResolver
+}
+
+func NewProcessKillInjector(pidResolver PIDResolver) *ProcessKillInjector {
+	return &ProcessKillInjector{pidResolver: pidResolver}
+}
+
+func (p *ProcessKillInjector) KillProcesses(ctx context.Context, containerID string, kill domainfault.ProcessKill) (int, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return 0, domainfault.ErrInvalidContainerID
+	}
+	if p.pidResolver == nil {
+		return 0, fmt.Errorf("pid resolver is required")
+	}
+
+	signalName, err := normalizeSignal(kill.Signal)
+	if err != nil {
+		return 0, err
+	}
+	signal := unix.SignalNum(signalName)
+	if signal == 0 {
+		return 0, fmt.Errorf("%w: %q", domainfault.ErrInvalidKillSignal, kill.Signal)
+	}
+
+	matcher, err := newProcessMatcher(kill)
+	if err != nil {
+		return 0, err
+	}
+
+	initPID, err := p.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return 0, fmt.Errorf("resolve container pid: %w", err)
+	}
+	namespace, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(initPID), "ns", "pid"))
+	if err != nil {
+		return 0, fmt.Errorf("read pid namespace of container %q: %w", containerID, err)
+	}
+
+	pids, err := namespacePIDs(namespace, initPID)
+	if err != nil {
+		return 0, err
+	}
+
+	signalled := 0
+	var errs []error
+	for _, pid := range pids {
+		comm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "comm"))
+		if err != nil {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+		if err != nil || !matcher.matches(string(comm), cmdline) {
+			continue
+		}
+
+		if err := unix.Kill(pid, signal); err != nil {
+			if errors.Is(err, unix.ESRCH) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("%w: pid %d: %v", domainfault.ErrProcessKillFailed, pid, err))
+			continue
+		}
+		signalled++
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return signalled, err
+	}
+	if signalled == 0 {
+		return 0, fmt.Errorf("%w: %q", domainfault.ErrNoMatchingProcess, containerID)
+	}
+
+	return signalled, nil
+}
+
+// namespacePIDs lists host PIDs of the processes in the given PID namespace, excluding the
+// namespace's init process. Processes that exit during the scan are skipped.
+func namespacePIDs(namespace string, initPID int) ([]int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("list processes: %w", err)
+	}
+
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == initPID {
+			continue
+		}
+		link, err := os.Readlink(filepath.Join("/proc", entry.Name(), "ns", "pid"))
+		if err != nil || link != namespace {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+
+	return pids, nil
+}
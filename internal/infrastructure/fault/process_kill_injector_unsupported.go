@@ -0,0 +1,23 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type ProcessKillInjector struct{}
+
+func NewProcessKillInjector(_ PIDResolver) *ProcessKillInjector {
+	return &ProcessKillInjector{}
+}
+
+func (p *ProcessKillInjector) KillProcesses(ctx context.Context, containerID string, kill domainfault.ProcessKill) (int, error) {
+	_ = ctx
+	_ = containerID
+	_ = kill
+	return 0, fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,38 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestProcessMatcher(t *testing.T) {
+	matcher, err := newProcessMatcher(domainfault.ProcessKill{Name: "python3", Cmdline: `celery .*worker`})
+	if err != nil {
+		t.Fatalf("newProcessMatcher returned error: %v", err)
+	}
+
+	tests := []struct {
+		comm    string
+		cmdline string
+		want    bool
+	}{
+		{"python3\n", "python3\x00-m\x00celery\x00-A\x00app\x00worker\x00", true},
+		{"python3\n", "python3\x00-m\x00celery\x00-A\x00app\x00beat\x00", false},
+		{"gunicorn\n", "python3\x00-m\x00celery\x00worker\x00", false},
+	}
+	for _, tt := range tests {
+		if got := matcher.matches(tt.comm, []byte(tt.cmdline)); got != tt.want {
+			t.Fatalf("matches(%q, %q) = %v, want %v", tt.comm, tt.cmdline, got, tt.want)
+		}
+	}
+}
+
+func TestNewProcessMatcher_Invalid(t *testing.T) {
+	for _, kill := range []domainfault.ProcessKill{{}, {Cmdline: "worker["}} {
+		if _, err := newProcessMatcher(kill); !errors.Is(err, domainfault.ErrInvalidProcessMatch) {
+			t.Fatalf("expected ErrInvalidProcessMatch for %#v, got %v", kill, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package fault
+
+import (
+	"context"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type PIDResolver interface {
+	ContainerPID(ctx context.Context, containerID string) (int, error)
+}
+
+type AddressResolver interface {
+	ContainerIPs(ctx context.Context, containerID string) ([]string, error)
+}
+
+// VolumeResolver finds where a named volume is mounted inside a container.
+type VolumeResolver interface {
+	VolumeDestination(ctx context.Context, containerID string, volume string) (string, error)
+}
+
+// IOLimitSource reports the per-device I/O limits a container was configured with.
+type IOLimitSource interface {
+	ConfiguredIOLimits(ctx context.Context, containerID string) ([]domainfault.IOThrottle, error)
+}
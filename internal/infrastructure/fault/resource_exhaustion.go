@@ -0,0 +1,101 @@
+package fault
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+const (
+	pidHogHelper = "pid-hog"
+	fdHogHelper  = "fd-hog"
+	// fdHogHeadroom is the number of descriptors the fd hog keeps free for the Go runtime when
+	// the container has no open-file limit to adopt.
+	fdHogHeadroom = 64
+)
+
+func pidHogArgs(cgroupDir string, target uint64, duration time.Duration) []string {
+	return helperArgs(pidHogHelper,
+		"-cgroup", cgroupDir,
+		"-target", fmt.Sprint(target),
+		"-duration", duration.String(),
+	)
+}
+
+func fdHogArgs(pid int, count uint64, limit uint64, duration time.Duration) []string {
+	return helperArgs(fdHogHelper,
+		"-pid", strconv.Itoa(pid),
+		"-count", fmt.Sprint(count),
+		"-limit", fmt.Sprint(limit),
+		"-duration", duration.String(),
+	)
+}
+
+func validateResourceExhaustion(exhaustion domainfault.ResourceExhaustion) error {
+	switch exhaustion.Resource {
+	case "pids", "files":
+	default:
+		return fmt.Errorf("%w: unknown resource %q", domainfault.ErrInvalidResourceExhaustion, exhaustion.Resource)
+	}
+	if (exhaustion.Count > 0) == (exhaustion.Percent > 0) {
+		return fmt.Errorf("%w: exactly one of count or percentage is required", domainfault.ErrInvalidResourceExhaustion)
+	}
+	if exhaustion.Count < 0 || exhaustion.Percent < 0 || exhaustion.Percent > 100 {
+		return domainfault.ErrInvalidResourceExhaustion
+	}
+	if exhaustion.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	return nil
+}
+
+// exhaustionCount resolves how many processes or descriptors an exhaustion adds when used of
+// limit are already taken; a zero limit means the resource is unlimited.
+func exhaustionCount(exhaustion domainfault.ResourceExhaustion, used uint64, limit uint64) (uint64, error) {
+	if exhaustion.Count > 0 {
+		return uint64(exhaustion.Count), nil
+	}
+	if limit == 0 {
+		// A share of an absent limit would exhaust the host instead of the container.
+		return 0, domainfault.ErrResourceLimitUnavailable
+	}
+	target := uint64(math.Ceil(float64(limit) * exhaustion.Percent / 100))
+	if target <= used {
+		return 0, nil
+	}
+	return target - used, nil
+}
+
+// parsePidsValue parses pids.max or pids.current. It reports false for "max".
+func parsePidsValue(raw string) (uint64, bool) {
+	value, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// parseOpenFilesLimit returns the soft "Max open files" limit from /proc/<pid>/limits. It
+// reports false when the limit is unlimited.
+func parseOpenFilesLimit(limits string) (uint64, bool) {
+	for _, line := range strings.Split(limits, "\n") {
+		rest, ok := strings.CutPrefix(line, "Max open files")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return 0, false
+		}
+		soft, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil || soft == 0 {
+			return 0, false
+		}
+		return soft, true
+	}
+	return 0, false
+}
@@ -0,0 +1,154 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// ResourceExhaustionInjector runs a pid or fd hog helper in the target container's cgroup.
+// The pid hog forks idle processes that count against the cgroup's pids.max; the fd hog takes
+// the open-file limit of the container's init process and opens idle sockets in the
+// container's network namespace up to it. Nothing in the container is changed, so killing the
+// helpers reverts the fault.
+type ResourceExhaustionInjector struct {
+	pidResolver PIDResolver
+}
+
+func NewResourceExhaustionInjector(pidResolver PIDResolver) *ResourceExhaustionInjector {
+	return &ResourceExhaustionInjector{pidResolver: pidResolver}
+}
+
+func (r *ResourceExhaustionInjector) ExhaustResource(ctx context.Context, containerID string, exhaustion domainfault.ResourceExhaustion) error {
+	if err := validateResourceExhaustion(exhaustion); err != nil {
+		return err
+	}
+
+	pid, err := r.containerPID(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	containerID = strings.TrimSpace(containerID)
+
+	var dir string
+	var args []string
+	switch exhaustion.Resource {
+	case "pids":
+		dir, err = containerCgroupDir(pid, "pids")
+		if err != nil {
+			return fmt.Errorf("locate cgroup of container %q: %w", containerID, err)
+		}
+		used, limit, err := pidsUsage(dir)
+		if err != nil {
+			return fmt.Errorf("read pids usage of container %q: %w", containerID, err)
+		}
+		count, err := exhaustionCount(exhaustion, used, limit)
+		if err != nil {
+			return fmt.Errorf("exhaust pids in container %q: %w", containerID, err)
+		}
+		args = pidHogArgs(dir, used+count, exhaustion.Duration)
+	case "files":
+		// The sockets are charged to the container's memory cgroup along with the hog.
+		dir, err = containerCgroupDir(pid, "memory")
+		if err != nil {
+			return fmt.Errorf("locate cgroup of container %q: %w", containerID, err)
+		}
+		limit, err := openFilesLimit(pid)
+		if err != nil {
+			return fmt.Errorf("read open-file limit of container %q: %w", containerID, err)
+		}
+		count, err := exhaustionCount(exhaustion, 0, limit)
+		if err != nil {
+			return fmt.Errorf("exhaust files in container %q: %w", containerID, err)
+		}
+		args = fdHogArgs(pid, count, limit, exhaustion.Duration)
+	}
+
+	cmd, err := startHelper(dir, args)
+	if err != nil {
+		return fmt.Errorf("exhaust %s in container %q: %w", exhaustion.Resource, containerID, err)
+	}
+	if err := waitHelper(ctx, cmd); err != nil {
+		return fmt.Errorf("exhaust %s in container %q: %w", exhaustion.Resource, containerID, err)
+	}
+	return nil
+}
+
+// RevertResourceExhaustion kills every pid and fd hog in the container's cgroups. Idle
+// processes die with their hog and the kernel closes the hog's sockets.
+func (r *ResourceExhaustionInjector) RevertResourceExhaustion(ctx context.Context, containerID string) error {
+	pid, err := r.containerPID(ctx, containerID)
+	if err != nil {
+		return err
+	}
+	containerID = strings.TrimSpace(containerID)
+
+	var errs []error
+	for _, hog := range []struct{ controller, kind string }{{"pids", pidHogHelper}, {"memory", fdHogHelper}} {
+		dir, err := containerCgroupDir(pid, hog.controller)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := killHelpers(dir, hog.kind); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("revert resource exhaustion in container %q: %w", containerID, err)
+	}
+	return nil
+}
+
+func (r *ResourceExhaustionInjector) containerPID(ctx context.Context, containerID string) (int, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return 0, domainfault.ErrInvalidContainerID
+	}
+	if r.pidResolver == nil {
+		return 0, fmt.Errorf("pid resolver is required")
+	}
+
+	pid, err := r.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return 0, fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+	return pid, nil
+}
+
+// pidsUsage returns pids.current and pids.max of the cgroup at dir; a zero limit means "max".
+func pidsUsage(dir string) (uint64, uint64, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, "pids.current"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+	used, ok := parsePidsValue(string(raw))
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: unexpected pids.current %q", domainfault.ErrCgroupUnavailable, strings.TrimSpace(string(raw)))
+	}
+
+	raw, err = os.ReadFile(filepath.Join(dir, "pids.max"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", domainfault.ErrCgroupUnavailable, err)
+	}
+	limit, _ := parsePidsValue(string(raw))
+	return used, limit, nil
+}
+
+// openFilesLimit returns the soft open-file limit of pid; zero means unlimited.
+func openFilesLimit(pid int) (uint64, error) {
+	raw, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "limits"))
+	if err != nil {
+		return 0, fmt.Errorf("read limits of pid %d: %w", pid, err)
+	}
+	limit, _ := parseOpenFilesLimit(string(raw))
+	return limit, nil
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type ResourceExhaustionInjector struct{}
+
+func NewResourceExhaustionInjector(_ PIDResolver) *ResourceExhaustionInjector {
+	return &ResourceExhaustionInjector{}
+}
+
+func (r *ResourceExhaustionInjector) ExhaustResource(ctx context.Context, containerID string, exhaustion domainfault.ResourceExhaustion) error {
+	_ = ctx
+	_ = containerID
+	_ = exhaustion
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (r *ResourceExhaustionInjector) RevertResourceExhaustion(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
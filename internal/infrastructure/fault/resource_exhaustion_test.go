@@ -0,0 +1,74 @@
+package fault
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestExhaustionCount(t *testing.T) {
+	cases := []struct {
+		name       string
+		exhaustion domainfault.ResourceExhaustion
+		used       uint64
+		limit      uint64
+		want       uint64
+	}{
+		{"count", domainfault.ResourceExhaustion{Count: 200}, 12, 0, 200},
+		{"percent", domainfault.ResourceExhaustion{Percent: 90}, 12, 100, 78},
+		{"percent already reached", domainfault.ResourceExhaustion{Percent: 10}, 12, 100, 0},
+	}
+	for _, tc := range cases {
+		got, err := exhaustionCount(tc.exhaustion, tc.used, tc.limit)
+		if err != nil {
+			t.Fatalf("%s: exhaustionCount returned error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+
+	if _, err := exhaustionCount(domainfault.ResourceExhaustion{Percent: 50}, 0, 0); !errors.Is(err, domainfault.ErrResourceLimitUnavailable) {
+		t.Fatalf("expected ErrResourceLimitUnavailable, got %v", err)
+	}
+}
+
+func TestValidateResourceExhaustion(t *testing.T) {
+	invalid := []domainfault.ResourceExhaustion{
+		{Resource: "memory", Count: 1, Duration: time.Second},
+		{Resource: "pids", Duration: time.Second},
+		{Resource: "pids", Count: 10, Percent: 50, Duration: time.Second},
+		{Resource: "files", Percent: 150, Duration: time.Second},
+	}
+	for _, exhaustion := range invalid {
+		if err := validateResourceExhaustion(exhaustion); !errors.Is(err, domainfault.ErrInvalidResourceExhaustion) {
+			t.Fatalf("expected ErrInvalidResourceExhaustion for %#v, got %v", exhaustion, err)
+		}
+	}
+	if err := validateResourceExhaustion(domainfault.ResourceExhaustion{Resource: "pids", Count: 1}); !errors.Is(err, domainfault.ErrInvalidFaultDuration) {
+		t.Fatalf("expected ErrInvalidFaultDuration, got %v", err)
+	}
+}
+
+func TestParsePidsValue(t *testing.T) {
+	if got, ok := parsePidsValue("512\n"); !ok || got != 512 {
+		t.Fatalf("expected 512, got %d (%v)", got, ok)
+	}
+	if _, ok := parsePidsValue("max\n"); ok {
+		t.Fatalf("expected max to be reported as unlimited")
+	}
+}
+
+func TestParseOpenFilesLimit(t *testing.T) {
+	limits := "Limit                     Soft Limit           Hard Limit           Units     \n" +
+		"Max processes             unlimited            unlimited            processes \n" +
+		"Max open files            1024                 524288               files     \n"
+	if got, ok := parseOpenFilesLimit(limits); !ok || got != 1024 {
+		t.Fatalf("expected soft limit 1024, got %d (%v)", got, ok)
+	}
+	if _, ok := parseOpenFilesLimit("Max open files            unlimited            unlimited            files\n"); ok {
+		t.Fatalf("expected unlimited open files to be reported as unavailable")
+	}
+}
@@ -0,0 +1,147 @@
+//go:build linux
+
+package fault
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// runPidHog starts idle `sleep` processes in the cgroup until its pids.current reaches the
+// target or the kernel refuses to fork, and holds them for the duration. The children are
+// killed with the hog, so killing the hog reverts the fault.
+func runPidHog(args []string) error {
+	flags := flag.NewFlagSet(pidHogHelper, flag.ContinueOnError)
+	dir := flags.String("cgroup", "", "cgroup directory to fill")
+	target := flags.Uint64("target", 0, "pids.current to reach")
+	duration := flags.Duration("duration", 0, "how long to hold the processes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" || *duration <= 0 {
+		return fmt.Errorf("invalid pid hog parameters")
+	}
+
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		return fmt.Errorf("locate sleep: %w", err)
+	}
+	deadline := time.Now().Add(*duration)
+	seconds := strconv.Itoa(int(math.Ceil(duration.Seconds())) + 1)
+
+	var children []*exec.Cmd
+	defer func() {
+		for _, child := range children {
+			_ = child.Process.Kill()
+			_ = child.Wait()
+		}
+	}()
+
+	for {
+		raw, err := os.ReadFile(filepath.Join(*dir, "pids.current"))
+		if err != nil {
+			return fmt.Errorf("read pids.current: %w", err)
+		}
+		if current, ok := parsePidsValue(string(raw)); !ok || current >= *target {
+			break
+		}
+
+		child := exec.Command(sleep, seconds)
+		child.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+		if err := child.Start(); err != nil {
+			if errors.Is(err, syscall.EAGAIN) {
+				// pids.max is reached.
+				break
+			}
+			return fmt.Errorf("start idle process: %w", err)
+		}
+		children = append(children, child)
+	}
+
+	time.Sleep(time.Until(deadline))
+	return nil
+}
+
+// runFDHog adopts the open-file limit of a container process, opens idle sockets in its
+// network namespace up to that limit and holds them for the duration. Each socket is bound to
+// an ephemeral port while the namespace has ports left. The kernel closes the sockets when the
+// hog exits, so killing the hog reverts the fault.
+func runFDHog(args []string) error {
+	flags := flag.NewFlagSet(fdHogHelper, flag.ContinueOnError)
+	pid := flags.Int("pid", 0, "process whose network namespace is used")
+	count := flags.Uint64("count", 0, "sockets to open")
+	limit := flags.Uint64("limit", 0, "open-file limit of the container, zero for unlimited")
+	duration := flags.Duration("duration", 0, "how long to hold the sockets")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *pid <= 0 || *duration <= 0 {
+		return fmt.Errorf("invalid fd hog parameters")
+	}
+
+	deadline := time.Now().Add(*duration)
+
+	var rlimit unix.Rlimit
+	if err := unix.Getrlimit(unix.RLIMIT_NOFILE, &rlimit); err != nil {
+		return fmt.Errorf("read open-file limit: %w", err)
+	}
+	want := *limit
+	if want == 0 {
+		want = max(rlimit.Cur, *count+fdHogHeadroom)
+	}
+	if rlimit.Cur != want {
+		rlimit.Cur = want
+		rlimit.Max = max(rlimit.Max, want)
+		if err := unix.Setrlimit(unix.RLIMIT_NOFILE, &rlimit); err != nil {
+			return fmt.Errorf("set open-file limit to %d: %w", want, err)
+		}
+	}
+
+	var fds []int
+	err := inNetworkNamespace(*pid, func() error {
+		var err error
+		fds, err = openIdleSockets(*count)
+		return err
+	})
+	defer func() {
+		for _, fd := range fds {
+			_ = unix.Close(fd)
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	time.Sleep(time.Until(deadline))
+	return nil
+}
+
+// openIdleSockets opens up to count TCP sockets in the current network namespace. Running into
+// the open-file limit or out of socket memory ends the loop without an error, since that is
+// the limit the hog is after.
+func openIdleSockets(count uint64) ([]int, error) {
+	var fds []int
+	for uint64(len(fds)) < count {
+		fd, err := unix.Socket(unix.AF_INET, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+		if errors.Is(err, unix.EMFILE) || errors.Is(err, unix.ENFILE) || errors.Is(err, unix.ENOBUFS) || errors.Is(err, unix.ENOMEM) {
+			break
+		}
+		if err != nil {
+			return fds, fmt.Errorf("open socket: %w", err)
+		}
+		// Once the ephemeral ports are used up the socket is still held, just unbound.
+		_ = unix.Bind(fd, &unix.SockaddrInet4{})
+		fds = append(fds, fd)
+	}
+	return fds, nil
+}
@@ -0,0 +1,38 @@
+//go:build linux
+
+package fault
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestOpenIdleSockets_HoldsDescriptors(t *testing.T) {
+	before, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skipf("read /proc/self/fd: %v", err)
+	}
+
+	fds, err := openIdleSockets(8)
+	defer func() {
+		for _, fd := range fds {
+			_ = unix.Close(fd)
+		}
+	}()
+	if err != nil {
+		t.Fatalf("openIdleSockets returned error: %v", err)
+	}
+	if len(fds) != 8 {
+		t.Fatalf("expected 8 sockets, got %d", len(fds))
+	}
+
+	after, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Fatalf("read /proc/self/fd: %v", err)
+	}
+	if len(after)-len(before) != 8 {
+		t.Fatalf("expected 8 more open descriptors, got %d more", len(after)-len(before))
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package fault
+
+import (
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func runPidHog(args []string) error {
+	_ = args
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func runFDHog(args []string) error {
+	_ = args
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
@@ -0,0 +1,25 @@
+//go:build !unix
+
+package fault
+
+import (
+	"fmt"
+	"os"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func prepareStateDir(dir string) error {
+	_ = dir
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func checkStateDir(dir string) error {
+	_ = dir
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func openStateFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	_, _, _ = path, flag, perm
+	return nil, fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
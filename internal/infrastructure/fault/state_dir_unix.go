@@ -0,0 +1,49 @@
+//go:build unix
+
+package fault
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// prepareStateDir creates dir and its parent for the current user only and checks that
+// nobody else can plant entries in them.
+func prepareStateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+	for _, d := range []string{filepath.Dir(dir), dir} {
+		if err := checkStateDir(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStateDir reports an error unless dir is a real directory owned by the current user
+// that no other user can write to.
+func checkStateDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("state directory %s is not a directory", dir)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || int(stat.Uid) != os.Geteuid() {
+		return fmt.Errorf("state directory %s is not owned by uid %d", dir, os.Geteuid())
+	}
+	if info.Mode().Perm()&0o022 != 0 {
+		return fmt.Errorf("state directory %s is writable by other users", dir)
+	}
+	return nil
+}
+
+// openStateFile opens a file in a state directory without following a symlink in its place.
+func openStateFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(path, flag|syscall.O_NOFOLLOW, perm)
+}
@@ -0,0 +1,71 @@
+//go:build unix
+
+package fault
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLedger_RejectsSymlinkedDirectory(t *testing.T) {
+	root := t.TempDir()
+	target := filepath.Join(root, "elsewhere")
+	if err := os.Mkdir(target, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Symlink(target, filepath.Join(root, "disk-fill")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	l := newBallastLedger()
+	l.dir = filepath.Join(root, "disk-fill")
+	if err := l.record("postgres", "/var/lib/postgresql/data"); err == nil {
+		t.Fatalf("expected a symlinked ledger directory to be rejected")
+	}
+	if _, err := l.entries("postgres"); err == nil {
+		t.Fatalf("expected a symlinked ledger directory to be rejected on read")
+	}
+}
+
+func TestLedger_RejectsDirectoryWritableByOthers(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "disk-fill")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Chmod(dir, 0o777); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+
+	l := newBallastLedger()
+	l.dir = dir
+	if _, err := l.entries("postgres"); err == nil {
+		t.Fatalf("expected a world-writable ledger directory to be rejected")
+	}
+}
+
+func TestLedger_DoesNotFollowSymlinkedEntry(t *testing.T) {
+	l := newBallastLedger()
+	l.dir = filepath.Join(t.TempDir(), "disk-fill")
+	if err := prepareStateDir(l.dir); err != nil {
+		t.Fatalf("prepareStateDir returned error: %v", err)
+	}
+
+	planted := filepath.Join(t.TempDir(), "planted")
+	if err := os.WriteFile(planted, []byte(`["/etc"]`), 0o600); err != nil {
+		t.Fatalf("write planted file: %v", err)
+	}
+	if err := os.Symlink(planted, l.path("postgres")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	if dirs, err := l.entries("postgres"); err == nil {
+		t.Fatalf("expected a symlinked entry to be rejected, got %v", dirs)
+	}
+	if err := l.record("postgres", "/tmp"); err == nil {
+		t.Fatalf("expected writing through a symlinked entry to fail")
+	}
+	if raw, _ := os.ReadFile(planted); string(raw) != `["/etc"]` {
+		t.Fatalf("planted file was overwritten: %q", raw)
+	}
+}
@@ -0,0 +1,130 @@
+package fault
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// tcpResetSweepInterval is how often established connections are listed when no rate is set.
+const tcpResetSweepInterval = time.Second
+
+// tcpConn is an established TCP connection as seen from inside the container.
+type tcpConn struct {
+	local netip.AddrPort
+	peer  netip.AddrPort
+}
+
+func validateTCPReset(reset domainfault.TCPReset) error {
+	if reset.Duration <= 0 {
+		return domainfault.ErrInvalidFaultDuration
+	}
+	if reset.Rate < 0 || math.IsNaN(reset.Rate) || math.IsInf(reset.Rate, 0) {
+		return fmt.Errorf("%w: rate must be zero or positive", domainfault.ErrInvalidFrequency)
+	}
+	return nil
+}
+
+// resetInterval is the pause between two resets at the fault's rate, or between two sweeps
+// when every matching connection is reset.
+func resetInterval(rate float64) time.Duration {
+	if rate <= 0 {
+		return tcpResetSweepInterval
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+	if interval < time.Millisecond {
+		return time.Millisecond
+	}
+	return interval
+}
+
+// parseEstablished reads the output of `ss -Htn state established`: Recv-Q, Send-Q, local and
+// peer address per line. IPv4-mapped IPv6 addresses are unmapped so they match IPv4 CIDRs.
+func parseEstablished(output string) ([]tcpConn, error) {
+	var conns []tcpConn
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("%w: unexpected ss output %q", domainfault.ErrSSCommandFailed, line)
+		}
+
+		local, err := parseSocketAddr(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		peer, err := parseSocketAddr(fields[3])
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, tcpConn{local: local, peer: peer})
+	}
+	return conns, nil
+}
+
+func parseSocketAddr(raw string) (netip.AddrPort, error) {
+	addrPort, err := netip.ParseAddrPort(raw)
+	if err != nil {
+		return netip.AddrPort{}, fmt.Errorf("%w: unexpected socket address %q", domainfault.ErrSSCommandFailed, raw)
+	}
+	return netip.AddrPortFrom(addrPort.Addr().Unmap().WithZone(""), addrPort.Port()), nil
+}
+
+// tcpMatcher selects connections whose peer is in one of prefixes and that use one of ports on
+// either end. An empty list matches everything.
+type tcpMatcher struct {
+	prefixes []netip.Prefix
+	ports    []int
+}
+
+func newTCPMatcher(cidrs []string, ports []int) (tcpMatcher, error) {
+	matcher := tcpMatcher{ports: ports}
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return tcpMatcher{}, fmt.Errorf("%w: %q", domainfault.ErrInvalidDestination, cidr)
+		}
+		matcher.prefixes = append(matcher.prefixes, prefix.Masked())
+	}
+	return matcher, nil
+}
+
+func (m tcpMatcher) matches(conn tcpConn) bool {
+	if len(m.prefixes) > 0 {
+		found := false
+		for _, prefix := range m.prefixes {
+			if prefix.Contains(conn.peer.Addr()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(m.ports) > 0 {
+		for _, port := range m.ports {
+			if int(conn.local.Port()) == port || int(conn.peer.Port()) == port {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// destroyArgs renders the ss invocation that destroys exactly conn.
+func destroyArgs(conn tcpConn) []string {
+	return []string{
+		"-K", "-Htn", "state", "established",
+		"src", conn.local.Addr().String(), "sport", "=", ":" + strconv.Itoa(int(conn.local.Port())),
+		"dst", conn.peer.Addr().String(), "dport", "=", ":" + strconv.Itoa(int(conn.peer.Port())),
+	}
+}
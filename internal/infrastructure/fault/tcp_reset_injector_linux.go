@@ -0,0 +1,189 @@
+//go:build linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// tcpResetMaxMisses is how many sweeps may match connections without destroying any before
+// the kernel is assumed to lack socket destruction.
+const tcpResetMaxMisses = 3
+
+// TCPResetInjector destroys established TCP connections of a container with `ss -K`, run
+// inside its network namespace. Both ends see the connection reset; the container's own
+// applications get ECONNABORTED and their peers an RST.
+type TCPResetInjector struct {
+	pidResolver     PIDResolver
+	addressResolver AddressResolver
+	nsenter         nsenterRunner
+
+	mu     sync.Mutex
+	active map[string]context.CancelFunc // ends the hold of a running fault
+}
+
+func NewTCPResetInjector(pidResolver PIDResolver, addressResolver AddressResolver) *TCPResetInjector {
+	return &TCPResetInjector{
+		pidResolver:     pidResolver,
+		addressResolver: addressResolver,
+		nsenter:         newNsenterRunner(),
+		active:          make(map[string]context.CancelFunc),
+	}
+}
+
+// ResetConnections resets matching connections for reset.Duration or until ctx is canceled.
+// Without a rate every matching connection is reset as soon as it is seen; with a rate one
+// randomly chosen connection is reset per interval.
+func (t *TCPResetInjector) ResetConnections(ctx context.Context, containerID string, reset domainfault.TCPReset) (int, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return 0, domainfault.ErrInvalidContainerID
+	}
+	if err := validateTCPReset(reset); err != nil {
+		return 0, err
+	}
+	if t.pidResolver == nil {
+		return 0, fmt.Errorf("pid resolver is required")
+	}
+
+	cidrs, err := resolveDestination(ctx, t.addressResolver, reset.Match)
+	if err != nil {
+		return 0, err
+	}
+	matcher, err := newTCPMatcher(cidrs, reset.Match.Ports)
+	if err != nil {
+		return 0, err
+	}
+
+	pid, err := t.pidResolver.ContainerPID(ctx, containerID)
+	if err != nil {
+		return 0, fmt.Errorf("resolve pid for container %q: %w", containerID, err)
+	}
+
+	holdCtx, release := context.WithTimeout(ctx, reset.Duration)
+	defer release()
+	t.track(containerID, release)
+	defer t.untrack(containerID)
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	ticker := time.NewTicker(resetInterval(reset.Rate))
+	defer ticker.Stop()
+
+	total, misses := 0, 0
+	for {
+		conns, err := t.established(holdCtx, pid, matcher)
+		if err != nil {
+			if holdCtx.Err() != nil {
+				return total, nil
+			}
+			return total, fmt.Errorf("list connections in container %q: %w", containerID, err)
+		}
+		if reset.Rate > 0 && len(conns) > 1 {
+			i := random.Intn(len(conns))
+			conns = conns[i : i+1]
+		}
+
+		killed := 0
+		for _, conn := range conns {
+			out, err := t.ss(holdCtx, pid, destroyArgs(conn))
+			if err != nil {
+				if holdCtx.Err() != nil {
+					return total, nil
+				}
+				return total, fmt.Errorf("reset connections in container %q: %w", containerID, err)
+			}
+			killed += countLines(out)
+		}
+		// ss skips sockets the kernel cannot destroy instead of failing. A connection may also
+		// close between listing and destroying it, so only repeated empty sweeps before any
+		// successful reset are taken to mean socket destruction is unavailable.
+		if len(conns) > 0 && killed == 0 && total == 0 {
+			misses++
+			if misses >= tcpResetMaxMisses {
+				return 0, fmt.Errorf("reset connections in container %q: %w", containerID, domainfault.ErrSocketDestroyFailed)
+			}
+		}
+		total += killed
+
+		select {
+		case <-holdCtx.Done():
+			return total, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RevertTCPReset stops a running reset for containerID. Destroyed connections cannot be
+// restored; clients are expected to reconnect.
+func (t *TCPResetInjector) RevertTCPReset(ctx context.Context, containerID string) error {
+	_ = ctx
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return domainfault.ErrInvalidContainerID
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cancel, ok := t.active[containerID]; ok {
+		cancel()
+	}
+	return nil
+}
+
+func (t *TCPResetInjector) established(ctx context.Context, pid int, matcher tcpMatcher) ([]tcpConn, error) {
+	out, err := t.ss(ctx, pid, []string{"-Htn", "state", "established"})
+	if err != nil {
+		return nil, err
+	}
+	conns, err := parseEstablished(out)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := conns[:0]
+	for _, conn := range conns {
+		if matcher.matches(conn) {
+			matching = append(matching, conn)
+		}
+	}
+	return matching, nil
+}
+
+func (t *TCPResetInjector) ss(ctx context.Context, pid int, args []string) (string, error) {
+	return t.nsenter.run(ctx, pid, namespaceCommand{
+		namespaces: []string{"--net"},
+		binary:     "ss",
+		args:       args,
+		missingErr: domainfault.ErrSSMissing,
+		failedErr:  domainfault.ErrSSCommandFailed,
+	})
+}
+
+func (t *TCPResetInjector) track(containerID string, cancel context.CancelFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active[containerID] = cancel
+}
+
+func (t *TCPResetInjector) untrack(containerID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.active, containerID)
+}
+
+func countLines(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+type TCPResetInjector struct{}
+
+func NewTCPResetInjector(_ PIDResolver, _ AddressResolver) *TCPResetInjector {
+	return &TCPResetInjector{}
+}
+
+func (t *TCPResetInjector) ResetConnections(ctx context.Context, containerID string, reset domainfault.TCPReset) (int, error) {
+	_ = ctx
+	_ = containerID
+	_ = reset
+	return 0, fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
+
+func (t *TCPResetInjector) RevertTCPReset(ctx context.Context, containerID string) error {
+	_ = ctx
+	_ = containerID
+	return fmt.Errorf("%w", domainfault.ErrUnsupportedPlatform)
+}
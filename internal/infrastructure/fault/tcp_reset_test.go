@@ -0,0 +1,94 @@
+package fault
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func TestParseEstablished(t *testing.T) {
+	output := "0      0      172.20.0.5:5432    172.20.0.6:43210\n" +
+		"0      0      [::ffff:172.20.0.5]:5432    [::ffff:172.20.0.7]:51000\n" +
+		"0      0      [fe80::1%eth0]:22    [fe80::2%eth0]:40000\n\n"
+
+	conns, err := parseEstablished(output)
+	if err != nil {
+		t.Fatalf("parseEstablished returned error: %v", err)
+	}
+
+	want := []tcpConn{
+		{local: netip.MustParseAddrPort("172.20.0.5:5432"), peer: netip.MustParseAddrPort("172.20.0.6:43210")},
+		{local: netip.MustParseAddrPort("172.20.0.5:5432"), peer: netip.MustParseAddrPort("172.20.0.7:51000")},
+		{local: netip.MustParseAddrPort("[fe80::1]:22"), peer: netip.MustParseAddrPort("[fe80::2]:40000")},
+	}
+	if !reflect.DeepEqual(conns, want) {
+		t.Fatalf("expected %v, got %v", want, conns)
+	}
+
+	if _, err := parseEstablished("0 0 garbage"); !errors.Is(err, domainfault.ErrSSCommandFailed) {
+		t.Fatalf("expected ErrSSCommandFailed for truncated output, got %v", err)
+	}
+}
+
+func TestTCPMatcher(t *testing.T) {
+	matcher, err := newTCPMatcher([]string{"172.20.0.6/32", "10.0.0.0/8"}, []int{5432})
+	if err != nil {
+		t.Fatalf("newTCPMatcher returned error: %v", err)
+	}
+
+	tests := []struct {
+		local string
+		peer  string
+		want  bool
+	}{
+		{"172.20.0.5:5432", "172.20.0.6:43210", true},
+		{"172.20.0.5:41000", "10.1.2.3:5432", true},
+		{"172.20.0.5:5432", "172.20.0.7:43210", false},
+		{"172.20.0.5:8080", "172.20.0.6:43210", false},
+	}
+	for _, tt := range tests {
+		conn := tcpConn{local: netip.MustParseAddrPort(tt.local), peer: netip.MustParseAddrPort(tt.peer)}
+		if got := matcher.matches(conn); got != tt.want {
+			t.Fatalf("matches(%s -> %s) = %v, want %v", tt.local, tt.peer, got, tt.want)
+		}
+	}
+
+	portOnly, err := newTCPMatcher(nil, []int{6379})
+	if err != nil {
+		t.Fatalf("newTCPMatcher returned error: %v", err)
+	}
+	if !portOnly.matches(tcpConn{local: netip.MustParseAddrPort("172.20.0.5:50000"), peer: netip.MustParseAddrPort("192.168.1.9:6379")}) {
+		t.Fatalf("expected port-only matcher to match any peer on port 6379")
+	}
+}
+
+func TestDestroyArgs(t *testing.T) {
+	conn := tcpConn{local: netip.MustParseAddrPort("172.20.0.5:5432"), peer: netip.MustParseAddrPort("172.20.0.6:43210")}
+
+	want := []string{"-K", "-Htn", "state", "established", "src", "172.20.0.5", "sport", "=", ":5432", "dst", "172.20.0.6", "dport", "=", ":43210"}
+	if got := destroyArgs(conn); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestResetInterval(t *testing.T) {
+	if got := resetInterval(0); got != tcpResetSweepInterval {
+		t.Fatalf("expected sweep interval without a rate, got %s", got)
+	}
+	if got := resetInterval(4); got != 250*time.Millisecond {
+		t.Fatalf("expected 250ms at 4/s, got %s", got)
+	}
+}
+
+func TestValidateTCPReset(t *testing.T) {
+	if err := validateTCPReset(domainfault.TCPReset{Rate: 1}); !errors.Is(err, domainfault.ErrInvalidFaultDuration) {
+		t.Fatalf("expected ErrInvalidFaultDuration, got %v", err)
+	}
+	if err := validateTCPReset(domainfault.TCPReset{Rate: -1, Duration: time.Second}); !errors.Is(err, domainfault.ErrInvalidFrequency) {
+		t.Fatalf("expected ErrInvalidFrequency, got %v", err)
+	}
+}
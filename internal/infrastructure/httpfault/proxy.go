@@ -0,0 +1,195 @@
+// Package httpfault implements the L7 proxy behind the http fault: it forwards requests to an
+// upstream and delays or aborts a share of the requests that match the fault's routes.
+package httpfault
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+// paretoShape is the shape of the Pareto distribution used for "pareto" delays. Its mean
+// with scale 1 is paretoShape/(paretoShape-1), which is subtracted to centre samples on Delay.
+const paretoShape = 3.0
+
+// Target picks the upstream URL for an incoming request.
+type Target func(r *http.Request) *url.URL
+
+// StaticTarget sends every request to u.
+func StaticTarget(u *url.URL) Target {
+	return func(*http.Request) *url.URL { return u }
+}
+
+// LocalTarget sends requests to port on the address they were received on. Behind an
+// iptables REDIRECT that is the container's own address, so the upstream is the service the
+// request was originally meant for.
+func LocalTarget(port int) Target {
+	return func(r *http.Request) *url.URL {
+		host := "127.0.0.1"
+		if addr, ok := r.Context().Value(http.LocalAddrContextKey).(*net.TCPAddr); ok && addr.IP != nil && !addr.IP.IsUnspecified() {
+			host = addr.IP.String()
+		}
+		return &url.URL{Scheme: "http", Host: net.JoinHostPort(host, strconv.Itoa(port))}
+	}
+}
+
+// Proxy is an http.Handler that applies an HTTP fault in front of an upstream.
+type Proxy struct {
+	fault   domainfault.HTTPFault
+	forward *httputil.ReverseProxy
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewProxy builds a proxy for fault. transport may be nil to use http.DefaultTransport.
+func NewProxy(fault domainfault.HTTPFault, target Target, transport http.RoundTripper) (*Proxy, error) {
+	if err := Validate(fault); err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("%w: upstream target is required", domainfault.ErrInvalidHTTPFault)
+	}
+
+	return &Proxy{
+		fault: fault,
+		forward: &httputil.ReverseProxy{
+			Rewrite: func(pr *httputil.ProxyRequest) {
+				pr.SetURL(target(pr.In))
+				pr.Out.Host = pr.In.Host
+				pr.SetXForwarded()
+			},
+			Transport: transport,
+		},
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !p.matches(r) || !p.roll() {
+		p.forward.ServeHTTP(w, r)
+		return
+	}
+
+	if delay := p.sampleDelay(); delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if p.fault.AbortStatus != 0 {
+		http.Error(w, http.StatusText(p.fault.AbortStatus), p.fault.AbortStatus)
+		return
+	}
+	p.forward.ServeHTTP(w, r)
+}
+
+// Validate checks an HTTP fault independently of where the proxy runs.
+func Validate(fault domainfault.HTTPFault) error {
+	if !(fault.Percent > 0 && fault.Percent <= 100) {
+		return fmt.Errorf("%w: percentage must be greater than 0 and at most 100", domainfault.ErrInvalidHTTPFault)
+	}
+	if fault.AbortStatus != 0 && (fault.AbortStatus < 100 || fault.AbortStatus > 599) {
+		return fmt.Errorf("%w: abort status %d is not an HTTP status code", domainfault.ErrInvalidHTTPFault, fault.AbortStatus)
+	}
+	if fault.AbortStatus == 0 && fault.Delay.Delay <= 0 {
+		return fmt.Errorf("%w: an abort status or a delay is required", domainfault.ErrInvalidHTTPFault)
+	}
+	if fault.Delay.Delay < 0 || fault.Delay.Jitter < 0 {
+		return domainfault.ErrInvalidLatencyDuration
+	}
+	switch fault.Delay.Distribution {
+	case "", "normal", "pareto", "paretonormal":
+	default:
+		return fmt.Errorf("%w: %q", domainfault.ErrInvalidDistribution, fault.Delay.Distribution)
+	}
+	for _, route := range fault.Routes {
+		if route.Path != "" && !strings.HasPrefix(route.Path, "/") {
+			return fmt.Errorf("%w: route path %q must start with /", domainfault.ErrInvalidHTTPFault, route.Path)
+		}
+	}
+	return nil
+}
+
+// matches reports whether r is selected by one of the fault's routes.
+func (p *Proxy) matches(r *http.Request) bool {
+	if len(p.fault.Routes) == 0 {
+		return true
+	}
+	for _, route := range p.fault.Routes {
+		if matchRoute(route, r.Method, r.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchRoute(route domainfault.HTTPRoute, method string, path string) bool {
+	if route.Method != "" && !strings.EqualFold(route.Method, method) {
+		return false
+	}
+	if route.Path == "" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(route.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == route.Path
+}
+
+// roll reports whether a matching request is faulted, given the fault percentage.
+func (p *Proxy) roll() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rand.Float64()*100 < p.fault.Percent
+}
+
+// sampleDelay draws a delay around Delay. Without a distribution the jitter is uniform;
+// "normal" uses Jitter as the standard deviation and "pareto" adds a heavy upper tail.
+// Samples are never negative.
+func (p *Proxy) sampleDelay() time.Duration {
+	l := p.fault.Delay
+	if l.Jitter <= 0 {
+		return l.Delay
+	}
+
+	p.mu.Lock()
+	var offset float64
+	switch l.Distribution {
+	case "normal":
+		offset = p.rand.NormFloat64()
+	case "pareto":
+		offset = p.pareto()
+	case "paretonormal":
+		offset = (p.rand.NormFloat64() + p.pareto()) / 2
+	default:
+		offset = p.rand.Float64()*2 - 1
+	}
+	p.mu.Unlock()
+
+	delay := l.Delay + time.Duration(offset*float64(l.Jitter))
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// pareto returns a centred Pareto sample; the caller holds p.mu.
+func (p *Proxy) pareto() float64 {
+	sample := math.Pow(1-p.rand.Float64(), -1/paretoShape)
+	return sample - paretoShape/(paretoShape-1)
+}
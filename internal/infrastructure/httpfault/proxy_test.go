@@ -0,0 +1,165 @@
+package httpfault
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+)
+
+func newTestProxy(t *testing.T, fault domainfault.HTTPFault) (*httptest.Server, *int) {
+	t.Helper()
+
+	hits := new(int)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		_, _ = io.WriteString(w, "upstream "+r.Method+" "+r.URL.Path)
+	}))
+	t.Cleanup(upstream.Close)
+
+	target, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatalf("parse upstream url: %v", err)
+	}
+	proxy, err := NewProxy(fault, StaticTarget(target), nil)
+	if err != nil {
+		t.Fatalf("NewProxy returned error: %v", err)
+	}
+
+	front := httptest.NewServer(proxy)
+	t.Cleanup(front.Close)
+	return front, hits
+}
+
+func do(t *testing.T, method string, rawURL string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, rawURL, strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestProxy_AbortsMatchingRoutesOnly(t *testing.T) {
+	front, hits := newTestProxy(t, domainfault.HTTPFault{
+		Routes:      []domainfault.HTTPRoute{{Method: "POST", Path: "/orders*"}},
+		Percent:     100,
+		AbortStatus: http.StatusServiceUnavailable,
+	})
+
+	status, _ := do(t, http.MethodPost, front.URL+"/orders/42")
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 for POST /orders/42, got %d", status)
+	}
+
+	status, body := do(t, http.MethodGet, front.URL+"/orders/42")
+	if status != http.StatusOK || body != "upstream GET /orders/42" {
+		t.Fatalf("expected GET to pass through, got %d %q", status, body)
+	}
+
+	status, _ = do(t, http.MethodPost, front.URL+"/payments")
+	if status != http.StatusOK {
+		t.Fatalf("expected POST /payments to pass through, got %d", status)
+	}
+	if *hits != 2 {
+		t.Fatalf("expected 2 upstream hits, got %d", *hits)
+	}
+}
+
+func TestProxy_DelaysBeforeForwarding(t *testing.T) {
+	front, hits := newTestProxy(t, domainfault.HTTPFault{
+		Percent: 100,
+		Delay:   domainfault.Latency{Delay: 50 * time.Millisecond},
+	})
+
+	start := time.Now()
+	status, _ := do(t, http.MethodGet, front.URL+"/health")
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected at least 50ms delay, got %s", elapsed)
+	}
+	if status != http.StatusOK || *hits != 1 {
+		t.Fatalf("expected delayed request to be forwarded, got %d with %d hits", status, *hits)
+	}
+}
+
+func TestProxy_PercentageSelectsShareOfRequests(t *testing.T) {
+	proxy, err := NewProxy(domainfault.HTTPFault{Percent: 10, AbortStatus: http.StatusInternalServerError}, StaticTarget(&url.URL{}), nil)
+	if err != nil {
+		t.Fatalf("NewProxy returned error: %v", err)
+	}
+
+	faulted := 0
+	for i := 0; i < 10000; i++ {
+		if proxy.roll() {
+			faulted++
+		}
+	}
+	if faulted < 800 || faulted > 1200 {
+		t.Fatalf("expected about 10%% of requests to be faulted, got %d of 10000", faulted)
+	}
+}
+
+func TestProxy_SampleDelayNeverNegative(t *testing.T) {
+	for _, distribution := range []string{"", "normal", "pareto", "paretonormal"} {
+		proxy, err := NewProxy(domainfault.HTTPFault{
+			Percent: 100,
+			Delay:   domainfault.Latency{Delay: 10 * time.Millisecond, Jitter: 50 * time.Millisecond, Distribution: distribution},
+		}, StaticTarget(&url.URL{}), nil)
+		if err != nil {
+			t.Fatalf("NewProxy returned error: %v", err)
+		}
+		for i := 0; i < 1000; i++ {
+			if d := proxy.sampleDelay(); d < 0 {
+				t.Fatalf("%q distribution produced negative delay %s", distribution, d)
+			}
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []domainfault.HTTPFault{
+		{Percent: 0, AbortStatus: 503},
+		{Percent: 10},
+		{Percent: 10, AbortStatus: 99},
+		{Percent: 10, AbortStatus: 503, Routes: []domainfault.HTTPRoute{{Path: "orders"}}},
+	}
+	for _, fault := range tests {
+		if err := Validate(fault); !errors.Is(err, domainfault.ErrInvalidHTTPFault) {
+			t.Fatalf("expected ErrInvalidHTTPFault for %#v, got %v", fault, err)
+		}
+	}
+
+	err := Validate(domainfault.HTTPFault{Percent: 10, Delay: domainfault.Latency{Delay: time.Second, Jitter: time.Second, Distribution: "uniform"}})
+	if !errors.Is(err, domainfault.ErrInvalidDistribution) {
+		t.Fatalf("expected ErrInvalidDistribution, got %v", err)
+	}
+}
+
+func TestLocalTarget_UsesReceivingAddress(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	ctx := context.WithValue(req.Context(), http.LocalAddrContextKey, &net.TCPAddr{IP: net.ParseIP("172.20.0.5"), Port: 41000})
+
+	got := LocalTarget(8080)(req.WithContext(ctx))
+	if got.String() != "http://172.20.0.5:8080" {
+		t.Fatalf("expected http://172.20.0.5:8080, got %s", got)
+	}
+}
@@ -0,0 +1,54 @@
+package chaosdock
+
+import (
+	"github.com/lekhanpro/chaos-dock/internal/application/engine"
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	dockerinfra "github.com/lekhanpro/chaos-dock/internal/infrastructure/docker"
+	faultinfra "github.com/lekhanpro/chaos-dock/internal/infrastructure/fault"
+)
+
+// Docker is a connection to the Docker daemon that backs the built-in fault types.
+type Docker struct {
+	runtime *dockerinfra.Runtime
+}
+
+// NewDocker connects to the Docker daemon configured by the environment (DOCKER_HOST and
+// related variables).
+func NewDocker() (*Docker, error) {
+	runtime, err := dockerinfra.NewRuntimeFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &Docker{runtime: runtime}, nil
+}
+
+// Close releases the connection to the daemon.
+func (d *Docker) Close() error {
+	return d.runtime.Close()
+}
+
+// Registry returns a registry holding the built-in plugins backed by d. Custom plugins may be
+// registered on it next to them.
+func (d *Docker) Registry() (*Registry, error) {
+	return domainfault.NewBuiltinRegistry(faultinfra.NewInjectors(d.runtime))
+}
+
+// Runner returns a runner that executes experiments with the plugins in faults and resolves
+// target selectors against the running containers of d.
+func (d *Docker) Runner(faults *Registry) *Runner {
+	return &engine.Runner{Faults: faults, Targets: d.runtime}
+}
+
+// IsHelperInvocation reports whether args, normally os.Args, start a workload helper. Faults
+// such as cpu-stress and resource-exhaustion re-execute the running binary as a helper inside
+// the target's cgroup, so an embedding program must check this first thing in main and hand
+// the process over to RunHelper.
+func IsHelperInvocation(args []string) bool {
+	return faultinfra.IsHelperInvocation(args)
+}
+
+// RunHelper runs the workload helper selected by args and returns when it is done; the
+// program should then exit without doing anything else.
+func RunHelper(args []string) error {
+	return faultinfra.RunHelper(args)
+}
@@ -0,0 +1,47 @@
+package chaosdock
+
+import (
+	"github.com/lekhanpro/chaos-dock/internal/application/engine"
+	domainconfig "github.com/lekhanpro/chaos-dock/internal/domain/config"
+	domainfault "github.com/lekhanpro/chaos-dock/internal/domain/fault"
+	configinfra "github.com/lekhanpro/chaos-dock/internal/infrastructure/config"
+)
+
+// Plugin implements one or more fault types. Register custom plugins on a Registry to add
+// fault types that chaos-dock does not ship.
+type Plugin = domainfault.Plugin
+
+// Outcome is what a plugin's injection observed beyond success or failure.
+type Outcome = domainfault.Outcome
+
+// Registry maps fault types to plugins.
+type Registry = domainfault.Registry
+
+type (
+	Config           = domainconfig.ChaosConfig
+	Experiment       = domainconfig.Experiment
+	Fault            = domainconfig.Fault
+	TargetSelector   = domainconfig.TargetSelector
+	Runner           = engine.Runner
+	ExperimentResult = engine.ExperimentResult
+)
+
+var (
+	ErrUnknownFaultType   = domainfault.ErrUnknownFaultType
+	ErrDuplicateFaultType = domainfault.ErrDuplicateFaultType
+)
+
+// NewRegistry returns a registry holding only the given plugins.
+func NewRegistry(plugins ...Plugin) (*Registry, error) {
+	return domainfault.NewRegistry(plugins...)
+}
+
+// NewRunner returns a runner that executes experiments with the plugins in faults.
+func NewRunner(faults *Registry) *Runner {
+	return &Runner{Faults: faults}
+}
+
+// LoadConfig reads a YAML config and validates every fault with its plugin in faults.
+func LoadConfig(path string, faults *Registry) (Config, error) {
+	return configinfra.LoadChaosConfigWithFaults(path, faults)
+}